@@ -1,3 +1,5 @@
 module github.com/CarlosHe/go-policy-management
 
-go 1.19 
\ No newline at end of file
+go 1.19
+
+require gopkg.in/yaml.v3 v3.0.1
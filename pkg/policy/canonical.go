@@ -0,0 +1,274 @@
+package policy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CanonicalJSON encodes the policy so that semantically identical policies produce the same bytes.
+func (p Policy) CanonicalJSON() ([]byte, error) {
+	doc := map[string]interface{}{
+		"version":    p.Version,
+		"id":         p.ID,
+		"name":       p.Name,
+		"created_at": canonicalTime(p.CreatedAt),
+	}
+	if p.Description != "" {
+		doc["description"] = p.Description
+	}
+	if !p.UpdatedAt.IsZero() {
+		doc["updated_at"] = canonicalTime(p.UpdatedAt)
+	}
+	if p.Template != nil {
+		template := map[string]interface{}{"id": p.Template.ID}
+		if p.Template.Version != "" {
+			template["version"] = p.Template.Version
+		}
+		if len(p.Template.Parameters) > 0 {
+			parameters, err := canonicalValue(p.Template.Parameters)
+			if err != nil {
+				return nil, fmt.Errorf("template parameters: %v", err)
+			}
+			template["parameters"] = parameters
+		}
+		doc["template"] = template
+	}
+
+	statements := make([]interface{}, len(p.Statements))
+	for i, statement := range p.Statements {
+		s, err := canonicalStatement(statement)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %v", i, err)
+		}
+		statements[i] = s
+	}
+	doc["statements"] = statements
+
+	return encodeCanonical(doc)
+}
+
+// Hash returns the hex-encoded SHA-256 digest of CanonicalJSON.
+func (p Policy) Hash() (string, error) {
+	data, err := p.CanonicalJSON()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ETag returns Hash as a strong HTTP entity tag.
+func (p Policy) ETag() (string, error) {
+	hash, err := p.Hash()
+	if err != nil {
+		return "", err
+	}
+	return `"` + hash + `"`, nil
+}
+
+func canonicalStatement(s Statement) (map[string]interface{}, error) {
+	statement := map[string]interface{}{
+		"effect":    string(s.Effect),
+		"actions":   sortedUnique(len(s.Actions), func(i int) string { return string(s.Actions[i]) }),
+		"resources": sortedUnique(len(s.Resources), func(i int) string { return string(s.Resources[i]) }),
+	}
+	if s.ID != "" {
+		statement["id"] = s.ID
+	}
+	if len(s.Principals) > 0 {
+		statement["principals"] = sortedUnique(len(s.Principals), func(i int) string { return string(s.Principals[i]) })
+	}
+
+	if len(s.Conditions) > 0 {
+		encoded := make([]string, len(s.Conditions))
+		for i, c := range s.Conditions {
+			data, err := c.CanonicalJSON()
+			if err != nil {
+				return nil, fmt.Errorf("condition %d: %v", i, err)
+			}
+			encoded[i] = string(data)
+		}
+		conditions := make([]interface{}, 0, len(encoded))
+		for _, c := range sortedUnique(len(encoded), func(i int) string { return encoded[i] }) {
+			conditions = append(conditions, json.RawMessage(c))
+		}
+		statement["conditions"] = conditions
+	}
+	return statement, nil
+}
+
+// CanonicalJSON encodes the condition as it appears in Policy.CanonicalJSON.
+func (c Condition) CanonicalJSON() ([]byte, error) {
+	value, err := canonicalConditionValue(c.Operator, c.Value)
+	if err != nil {
+		return nil, err
+	}
+	return encodeCanonical(map[string]interface{}{
+		"operator": string(c.Operator),
+		"key":      string(c.Key),
+		"value":    value,
+	})
+}
+
+func sortedUnique(n int, item func(int) string) []string {
+	seen := make(map[string]bool, n)
+	result := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		if s := item(i); !seen[s] {
+			seen[s] = true
+			result = append(result, s)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func canonicalTime(t time.Time) string {
+	return t.UTC().Truncate(time.Second).Format(time.RFC3339)
+}
+
+func canonicalConditionValue(operator ConditionOperator, value interface{}) (interface{}, error) {
+	if family, _ := operator.Family(); family != NumericFamily {
+		return canonicalValue(value)
+	}
+	switch v := value.(type) {
+	case string:
+		if _, err := ParseDecimal(v); err == nil {
+			return canonicalNumber(v)
+		}
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized, err := canonicalConditionValue(operator, item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = normalized
+		}
+		return items, nil
+	}
+	return canonicalValue(value)
+}
+
+func canonicalValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, bool, string:
+		return v, nil
+	case json.Number:
+		return canonicalNumber(string(v))
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano), nil
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized, err := canonicalValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = normalized
+		}
+		return items, nil
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized, err := canonicalValue(item)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = normalized
+		}
+		return m, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(fmt.Sprint(rv.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return json.Number(fmt.Sprint(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("unsupported number %v", f)
+		}
+		bits := 64
+		if rv.Kind() == reflect.Float32 {
+			bits = 32
+		}
+		return canonicalNumber(strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return canonicalValue(decoded)
+}
+
+func canonicalNumber(s string) (json.Number, error) {
+	s = strings.TrimSpace(s)
+	if !decimalPattern.MatchString(s) {
+		return "", fmt.Errorf("invalid number %q", s)
+	}
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign = "-"
+	}
+	mantissa, exponent := strings.TrimLeft(s, "+-"), new(big.Int)
+	if i := strings.IndexAny(mantissa, "eE"); i >= 0 {
+		exponent.SetString(mantissa[i+1:], 10)
+		mantissa = mantissa[:i]
+	}
+	integer, fraction := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		integer, fraction = mantissa[:i], mantissa[i+1:]
+	}
+
+	digits := strings.TrimLeft(integer+fraction, "0")
+	exponent.Add(exponent, big.NewInt(int64(len(integer)-len(integer+fraction)+len(digits)-1)))
+	digits = strings.TrimRight(digits, "0")
+	if digits == "" {
+		return "0", nil
+	}
+	if !exponent.IsInt64() || exponent.Int64() > maxDecimalExponent || exponent.Int64() < -maxDecimalExponent {
+		text := digits[:1]
+		if len(digits) > 1 {
+			text += "." + digits[1:]
+		}
+		return json.Number(sign + text + "e" + exponent.String()), nil
+	}
+
+	e := int(exponent.Int64())
+	switch {
+	case e >= len(digits)-1:
+		return json.Number(sign + digits + strings.Repeat("0", e-len(digits)+1)), nil
+	case e >= 0:
+		return json.Number(sign + digits[:e+1] + "." + digits[e+1:]), nil
+	}
+	return json.Number(sign + "0." + strings.Repeat("0", -e-1) + digits), nil
+}
+
+func encodeCanonical(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,95 @@
+package casbin
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/convert"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+type ExportOptions struct {
+	// SkipUnsupported leaves out conditional Allow statements; a conditional Deny still fails.
+	SkipUnsupported bool
+}
+
+// Export writes policies as Casbin policy CSV rows for Model.
+func Export(policies []policy.Policy, opts ExportOptions) ([]byte, *convert.Report, error) {
+	report := &convert.Report{}
+	var buf bytes.Buffer
+	var unsupported bool
+
+	for i, p := range policies {
+		for j, statement := range p.Statements {
+			path := validator.JSONPointer("policies", i, "statements", j)
+			severity := validator.SeverityError
+			if opts.SkipUnsupported && statement.Effect == policy.Allow {
+				severity = validator.SeverityWarning
+			}
+
+			if statement.Effect != policy.Allow && statement.Effect != policy.Deny {
+				report.Add(convert.Issue{Severity: validator.SeverityError, Path: path + "/effect"}, "invalid effect %q", statement.Effect)
+				unsupported = true
+				continue
+			}
+			if len(statement.Conditions) > 0 {
+				report.Add(convert.Issue{Severity: severity, Path: path + "/conditions"}, "conditions have no Casbin equivalent")
+				unsupported = unsupported || severity == validator.SeverityError
+				continue
+			}
+
+			subjects := []string{"*"}
+			if len(statement.Principals) > 0 {
+				subjects = subjects[:0]
+				for k, principal := range statement.Principals {
+					subjects = append(subjects, string(principal))
+					warnKeyMatch(report, path+validator.JSONPointer("principals", k), string(principal))
+				}
+			}
+			for k, action := range statement.Actions {
+				warnKeyMatch(report, path+validator.JSONPointer("actions", k), string(action))
+			}
+			for k, resource := range statement.Resources {
+				if strings.Contains(string(resource), "**") {
+					report.Add(convert.Issue{Severity: validator.SeverityWarning, Path: path + validator.JSONPointer("resources", k)},
+						"globMatch has no \"**\" segment, %q only matches a single segment there", resource)
+				}
+			}
+
+			effect := strings.ToLower(string(statement.Effect))
+			for _, subject := range subjects {
+				for _, resource := range statement.Resources {
+					for _, action := range statement.Actions {
+						writeRow(&buf, "p", subject, string(resource), string(action), effect)
+					}
+				}
+			}
+		}
+	}
+
+	if unsupported {
+		return nil, report, &convert.ConversionError{Report: report}
+	}
+	return buf.Bytes(), report, nil
+}
+
+func writeRow(buf *bytes.Buffer, fields ...string) {
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if strings.ContainsAny(field, ",\"\r\n") || strings.TrimSpace(field) != field {
+			field = `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+		}
+		buf.WriteString(field)
+	}
+	buf.WriteByte('\n')
+}
+
+func warnKeyMatch(report *convert.Report, path, value string) {
+	if hasInnerWildcard(value) {
+		report.Add(convert.Issue{Severity: validator.SeverityWarning, Path: path},
+			"%q has a wildcard before its end, which keyMatch treats as matching the rest of the value", value)
+	}
+}
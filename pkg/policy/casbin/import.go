@@ -0,0 +1,146 @@
+package casbin
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/convert"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+type ImportOptions struct {
+	// ID and Name are used for the resulting policy.
+	ID   string
+	Name string
+	// CreatedAt defaults to the current time.
+	CreatedAt time.Time
+	// SkipUnsupported drops unsupported rows instead of failing.
+	SkipUnsupported bool
+}
+
+type policyRow struct {
+	line                    int
+	subject, object, action string
+	effect                  policy.Effect
+}
+
+// Import converts a Casbin policy CSV into a policy with one statement per "p" row.
+func Import(data []byte, opts ImportOptions) (policy.Policy, *convert.Report, error) {
+	report := &convert.Report{}
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rows []policyRow
+	members := make(map[string][]string)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return policy.Policy{}, nil, fmt.Errorf("invalid Casbin policy CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		switch {
+		case record[0] == "p" && (len(record) == 4 || len(record) == 5):
+			row := policyRow{line: line, subject: record[1], object: record[2], action: record[3], effect: policy.Allow}
+			if len(record) == 5 {
+				switch strings.ToLower(record[4]) {
+				case "allow":
+				case "deny":
+					row.effect = policy.Deny
+				default:
+					report.Add(convert.Issue{Severity: validator.SeverityError, Line: line}, "unknown effect %q", record[4])
+					continue
+				}
+			}
+			rows = append(rows, row)
+		case record[0] == "g" && len(record) == 3:
+			members[record[2]] = append(members[record[2]], record[1])
+		case record[0] == "g":
+			report.Add(convert.Issue{Severity: validator.SeverityError, Line: line}, "role links with domains are not supported")
+		case record[0] == "p":
+			report.Add(convert.Issue{Severity: validator.SeverityError, Line: line}, "policy rows must have 3 or 4 values after \"p\", got %d", len(record)-1)
+		default:
+			report.Add(convert.Issue{Severity: validator.SeverityError, Line: line}, "policy type %q is not supported", record[0])
+		}
+	}
+
+	p := policy.Policy{
+		Version:   policy.PolicyVersion,
+		ID:        opts.ID,
+		Name:      opts.Name,
+		CreatedAt: opts.CreatedAt,
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now()
+	}
+
+	for _, row := range rows {
+		statement := policy.Statement{
+			ID:        fmt.Sprintf("line-%d", row.line),
+			Effect:    row.effect,
+			Actions:   []policy.Action{policy.Action(row.action)},
+			Resources: []policy.Resource{policy.Resource(row.object)},
+		}
+		if row.subject != "*" {
+			principals := expandRole(row.subject, members)
+			if len(principals) > 1 {
+				report.Add(convert.Issue{Severity: validator.SeverityInfo, Line: row.line}, "role %q expanded to principals %s", row.subject, strings.Join(principals[1:], ", "))
+			}
+			for _, principal := range principals {
+				statement.Principals = append(statement.Principals, policy.Principal(principal))
+			}
+		}
+		checkKeyMatch(report, row.line, "subject", row.subject)
+		checkKeyMatch(report, row.line, "action", row.action)
+		checkGlobMatch(report, row.line, row.object)
+		p.Statements = append(p.Statements, statement)
+	}
+
+	if len(report.Unsupported()) > 0 && !opts.SkipUnsupported {
+		return policy.Policy{}, report, &convert.ConversionError{Report: report}
+	}
+	return p, report, nil
+}
+
+func expandRole(subject string, members map[string][]string) []string {
+	principals := []string{subject}
+	seen := map[string]bool{subject: true}
+	for i := 0; i < len(principals); i++ {
+		for _, member := range members[principals[i]] {
+			if !seen[member] {
+				seen[member] = true
+				principals = append(principals, member)
+			}
+		}
+	}
+	return principals
+}
+
+func checkKeyMatch(report *convert.Report, line int, field, value string) {
+	if hasInnerWildcard(value) {
+		report.Add(convert.Issue{Severity: validator.SeverityWarning, Line: line},
+			"%s %q has a wildcard before its end, which keyMatch treats as matching the rest of the value", field, value)
+	}
+}
+
+func checkGlobMatch(report *convert.Report, line int, object string) {
+	if strings.ContainsAny(object, "?[") || strings.Contains(object, "**") {
+		report.Add(convert.Issue{Severity: validator.SeverityWarning, Line: line},
+			"object %q uses glob syntax that globMatch and the resource matcher interpret differently", object)
+	}
+}
+
+func hasInnerWildcard(value string) bool {
+	i := strings.Index(value, "*")
+	return i >= 0 && i < len(value)-1
+}
@@ -0,0 +1,15 @@
+package casbin
+
+// Model is the Casbin model that Export targets and Import assumes.
+const Model = `[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act, eft
+
+[policy_effect]
+e = some(where (p.eft == allow)) && !some(where (p.eft == deny))
+
+[matchers]
+m = keyMatch(r.sub, p.sub) && (p.obj == "*" || globMatch(r.obj, p.obj)) && keyMatch(r.act, p.act)
+`
@@ -0,0 +1,184 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+const ActionSeparator = ":"
+
+// Catalog is the set of actions, resource types and condition keys an application understands.
+type Catalog struct {
+	mu            sync.RWMutex
+	services      map[string]Service
+	actions       map[policy.Action]ActionDefinition
+	resourceTypes map[string]ResourceTypeDefinition
+	globalKeys    map[policy.ConditionKey]bool
+}
+
+func NewCatalog() *Catalog {
+	return &Catalog{
+		services:      make(map[string]Service),
+		actions:       make(map[policy.Action]ActionDefinition),
+		resourceTypes: make(map[string]ResourceTypeDefinition),
+		globalKeys:    make(map[policy.ConditionKey]bool),
+	}
+}
+
+func QualifiedAction(service, action string) policy.Action {
+	if service == "" {
+		return policy.Action(action)
+	}
+	return policy.Action(service + ActionSeparator + action)
+}
+
+func (c *Catalog) RegisterService(service Service) error {
+	resourceTypes := make(map[string]bool, len(service.ResourceTypes))
+	for _, resourceType := range service.ResourceTypes {
+		if resourceType.Name == "" {
+			return fmt.Errorf("service %q has a resource type without a name", service.Name)
+		}
+		if resourceTypes[resourceType.Name] {
+			return fmt.Errorf("service %q defines resource type %q twice", service.Name, resourceType.Name)
+		}
+		resourceTypes[resourceType.Name] = true
+	}
+
+	actions := make(map[policy.Action]bool, len(service.Actions))
+	for _, action := range service.Actions {
+		if action.Name == "" {
+			return fmt.Errorf("service %q has an action without a name", service.Name)
+		}
+		name := QualifiedAction(service.Name, action.Name)
+		if actions[name] {
+			return fmt.Errorf("service %q defines action %q twice", service.Name, action.Name)
+		}
+		actions[name] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.services[service.Name]; exists {
+		return fmt.Errorf("service %q is already registered", service.Name)
+	}
+	for _, resourceType := range service.ResourceTypes {
+		if _, exists := c.resourceTypes[resourceType.Name]; exists {
+			return fmt.Errorf("resource type %q is already registered", resourceType.Name)
+		}
+	}
+	for _, action := range service.Actions {
+		for _, resourceType := range action.ResourceTypes {
+			if !resourceTypes[resourceType] {
+				if _, exists := c.resourceTypes[resourceType]; !exists {
+					return fmt.Errorf("action %q refers to unknown resource type %q", action.Name, resourceType)
+				}
+			}
+		}
+		if _, exists := c.actions[QualifiedAction(service.Name, action.Name)]; exists {
+			return fmt.Errorf("action %q is already registered", QualifiedAction(service.Name, action.Name))
+		}
+	}
+
+	c.services[service.Name] = service
+	for _, resourceType := range service.ResourceTypes {
+		c.resourceTypes[resourceType.Name] = resourceType
+	}
+	for _, action := range service.Actions {
+		c.actions[QualifiedAction(service.Name, action.Name)] = action
+	}
+	return nil
+}
+
+// RegisterGlobalConditionKeys declares keys that apply to every action.
+func (c *Catalog) RegisterGlobalConditionKeys(keys ...policy.ConditionKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		c.globalKeys[key] = true
+	}
+}
+
+func (c *Catalog) Action(name policy.Action) (ActionDefinition, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	action, ok := c.actions[name]
+	return action, ok
+}
+
+func (c *Catalog) Actions() []policy.Action {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	actions := make([]policy.Action, 0, len(c.actions))
+	for name := range c.actions {
+		actions = append(actions, name)
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+	return actions
+}
+
+func (c *Catalog) ResourceType(name string) (ResourceTypeDefinition, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	resourceType, ok := c.resourceTypes[name]
+	return resourceType, ok
+}
+
+// SupportsConditionKey reports whether key may be used with action.
+func (c *Catalog) SupportsConditionKey(action policy.Action, key policy.ConditionKey) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.globalKeys[key] {
+		return true
+	}
+	definition, ok := c.actions[action]
+	if !ok {
+		return false
+	}
+	if containsKey(definition.ConditionKeys, key) {
+		return true
+	}
+	for _, resourceType := range definition.ResourceTypes {
+		if containsKey(c.resourceTypes[resourceType].ConditionKeys, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsResourceType reports whether action applies to resources of the given type.
+func (c *Catalog) SupportsResourceType(action policy.Action, resourceType string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	definition, ok := c.actions[action]
+	if !ok {
+		return false
+	}
+	if len(definition.ResourceTypes) == 0 {
+		return true
+	}
+	for _, name := range definition.ResourceTypes {
+		if name == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+func containsKey(keys []policy.ConditionKey, key policy.ConditionKey) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package catalog
+
+import "github.com/CarlosHe/go-policy-management/pkg/policy"
+
+type ResourceTypeDefinition struct {
+	Name          string                `json:"name"`
+	ConditionKeys []policy.ConditionKey `json:"condition_keys,omitempty"`
+}
+
+type ActionDefinition struct {
+	Name          string                `json:"name"`
+	ResourceTypes []string              `json:"resource_types,omitempty"`
+	ConditionKeys []policy.ConditionKey `json:"condition_keys,omitempty"`
+}
+
+// Service groups the actions and resource types of one application component.
+type Service struct {
+	Name          string                   `json:"name"`
+	Actions       []ActionDefinition       `json:"actions"`
+	ResourceTypes []ResourceTypeDefinition `json:"resource_types,omitempty"`
+}
@@ -0,0 +1,71 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+// Issue describes a construct that could not be converted or changed in conversion.
+type Issue struct {
+	Severity validator.Severity `json:"severity"`
+	Line     int                `json:"line,omitempty"`
+	Path     string             `json:"path,omitempty"`
+	Message  string             `json:"message"`
+}
+
+func (i Issue) Location() string {
+	if i.Path != "" || i.Line == 0 {
+		return i.Path
+	}
+	return fmt.Sprintf("line %d", i.Line)
+}
+
+// Report lists the issues found while converting policies.
+type Report struct {
+	Issues []Issue `json:"issues,omitempty"`
+}
+
+func (r *Report) Add(issue Issue, format string, args ...interface{}) {
+	issue.Message = fmt.Sprintf(format, args...)
+	r.Issues = append(r.Issues, issue)
+}
+
+// Unsupportedf reports a construct at path that could not be converted.
+func (r *Report) Unsupportedf(path, format string, args ...interface{}) {
+	r.Add(Issue{Severity: validator.SeverityError, Path: path}, format, args...)
+}
+
+func (r *Report) Warnf(path, format string, args ...interface{}) {
+	r.Add(Issue{Severity: validator.SeverityWarning, Path: path}, format, args...)
+}
+
+func (r *Report) Infof(path, format string, args ...interface{}) {
+	r.Add(Issue{Severity: validator.SeverityInfo, Path: path}, format, args...)
+}
+
+// Unsupported returns the constructs that could not be converted.
+func (r *Report) Unsupported() []Issue {
+	var issues []Issue
+	for _, issue := range r.Issues {
+		if issue.Severity == validator.SeverityError {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// ConversionError is returned when unsupported constructs were not skipped.
+type ConversionError struct {
+	Report *Report
+}
+
+func (e *ConversionError) Error() string {
+	unsupported := e.Report.Unsupported()
+	messages := make([]string, len(unsupported))
+	for i, issue := range unsupported {
+		messages[i] = fmt.Sprintf("%s: %s", issue.Location(), issue.Message)
+	}
+	return fmt.Sprintf("%d unsupported constructs: %s", len(unsupported), strings.Join(messages, "; "))
+}
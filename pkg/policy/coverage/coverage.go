@@ -0,0 +1,140 @@
+package coverage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+)
+
+const wildcardChars = "*?[{"
+
+// Coverage conservatively decides whether one statement matches every request another matches.
+type Coverage struct {
+	actionMatcher   condition.PatternMatcher
+	resourceMatcher condition.ResourceMatcher
+	starMatchesAll  bool
+}
+
+func NewCoverage(actionMatcher condition.PatternMatcher, resourceMatcher condition.ResourceMatcher) *Coverage {
+	_, starMatchesAll := actionMatcher.(*condition.RegexPatternMatcher)
+	return &Coverage{
+		actionMatcher:   actionMatcher,
+		resourceMatcher: resourceMatcher,
+		starMatchesAll:  starMatchesAll,
+	}
+}
+
+// NewDefaultCoverage matches patterns with the default condition factory settings.
+func NewDefaultCoverage() *Coverage {
+	return NewCoverage(
+		condition.NewRegexPatternMatcher(),
+		condition.NewHierarchicalResourceMatcherWithSyntax(condition.NewRegexPatternMatcher(), policy.DefaultResourceNameSyntax, false),
+	)
+}
+
+// NewCoverageFromProvider uses the matchers of the evaluator's condition provider.
+func NewCoverageFromProvider(provider evaluator.IConditionProvider) *Coverage {
+	return NewCoverage(provider.GetPatternMatcher(), provider.GetResourceMatcher())
+}
+
+func (c *Coverage) StatementCovers(general, specific policy.Statement) bool {
+	if !c.principalsCover(general.Principals, specific.Principals) {
+		return false
+	}
+	for _, action := range specific.Actions {
+		if !c.anyActionCovers(general.Actions, string(action)) {
+			return false
+		}
+	}
+	for _, resource := range specific.Resources {
+		if !c.anyResourceCovers(general.Resources, string(resource)) {
+			return false
+		}
+	}
+	return ConditionsSubset(general.Conditions, specific.Conditions)
+}
+
+func (c *Coverage) anyActionCovers(patterns []policy.Action, specific string) bool {
+	for _, pattern := range patterns {
+		general := string(pattern)
+		if general == specific || (general == "*" && c.starMatchesAll) {
+			return true
+		}
+		if !HasWildcard(specific) && c.actionMatcher.MatchesPattern(specific, general) {
+			return true
+		}
+		if !c.starMatchesAll {
+			continue
+		}
+		prefix := strings.TrimSuffix(general, "*")
+		if len(prefix) == len(general)-1 && !HasWildcard(prefix) && strings.HasPrefix(specific, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Coverage) principalsCover(general, specific []policy.Principal) bool {
+	if len(general) == 0 {
+		return true
+	}
+	if len(specific) == 0 {
+		return false
+	}
+	for _, principal := range specific {
+		covered := false
+		for _, pattern := range general {
+			if pattern == principal || (pattern == "*" && c.starMatchesAll) ||
+				(!HasWildcard(string(principal)) && c.actionMatcher.MatchesPattern(string(principal), string(pattern))) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Coverage) anyResourceCovers(patterns []policy.Resource, specific string) bool {
+	for _, pattern := range patterns {
+		general := string(pattern)
+		if general == specific || general == "*" {
+			return true
+		}
+		if !HasWildcard(specific) && c.resourceMatcher.MatchesResource(specific, general) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWildcard reports whether pattern contains a wildcard or pattern character.
+func HasWildcard(pattern string) bool {
+	return strings.ContainsAny(pattern, wildcardChars)
+}
+
+// ConditionsSubset reports whether every condition in subset also appears in superset.
+func ConditionsSubset(subset, superset []policy.Condition) bool {
+	for _, cond := range subset {
+		found := false
+		for _, other := range superset {
+			if sameCondition(cond, other) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func sameCondition(a, b policy.Condition) bool {
+	return a.Operator == b.Operator && a.Key == b.Key && fmt.Sprint(a.Value) == fmt.Sprint(b.Value)
+}
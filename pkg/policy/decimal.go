@@ -0,0 +1,36 @@
+package policy
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxDecimalLength   = 4096
+	maxDecimalExponent = 1000
+)
+
+var decimalPattern = regexp.MustCompile(`^[+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:[eE]([+-]?[0-9]+))?$`)
+
+// ParseDecimal parses a decimal string such as "1.50" or "-2e3" exactly.
+func ParseDecimal(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+	match := decimalPattern.FindStringSubmatch(s)
+	if len(s) > maxDecimalLength || match == nil {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	if match[1] != "" {
+		exponent, err := strconv.Atoi(match[1])
+		if err != nil || exponent > maxDecimalExponent || exponent < -maxDecimalExponent {
+			return nil, fmt.Errorf("exponent out of range in number %q", s)
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	return r, nil
+}
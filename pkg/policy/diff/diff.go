@@ -0,0 +1,401 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+type ChangeKind string
+
+const (
+	Added   ChangeKind = "added"
+	Removed ChangeKind = "removed"
+	Changed ChangeKind = "changed"
+)
+
+// Change is one semantic difference between two policies.
+type Change struct {
+	Kind      ChangeKind
+	Path      string
+	Statement string
+	Field     string
+	Old       interface{}
+	New       interface{}
+}
+
+// Diff lists the changes from one policy to another with a JSON Patch between them.
+type Diff struct {
+	Changes []Change
+	Patch   Patch
+}
+
+var policyFields = []string{"version", "id", "name", "description", "created_at", "updated_at", "template"}
+
+// Compare diffs two policies, matching statements by ID or position.
+func Compare(old, new policy.Policy) (*Diff, error) {
+	oldDoc, err := toDocument(old)
+	if err != nil {
+		return nil, err
+	}
+	newDoc, err := toDocument(new)
+	if err != nil {
+		return nil, err
+	}
+	d := &Diff{}
+
+	for _, field := range policyFields {
+		oldValue, inOld := oldDoc[field]
+		newValue, inNew := newDoc[field]
+		if inOld == inNew && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		path := validator.JSONPointer(field)
+		switch {
+		case !inOld:
+			d.add(Change{Kind: Added, Path: path, Field: field, New: newValue})
+			d.Patch = append(d.Patch, Operation{Op: "add", Path: path, Value: newValue})
+		case !inNew:
+			d.add(Change{Kind: Removed, Path: path, Field: field, Old: oldValue})
+			d.Patch = append(d.Patch, Operation{Op: "remove", Path: path})
+		default:
+			d.add(Change{Kind: Changed, Path: path, Field: field, Old: oldValue, New: newValue})
+			d.Patch = append(d.Patch, Operation{Op: "replace", Path: path, Value: newValue})
+		}
+	}
+
+	if err := d.compareStatements(old.Statements, new.Statements, oldDoc, newDoc); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *Diff) compareStatements(old, new []policy.Statement, oldDoc, newDoc map[string]interface{}) error {
+	oldKeys := statementKeys(old)
+	newKeys := statementKeys(new)
+	oldIndex := indexOf(oldKeys)
+	newIndex := indexOf(newKeys)
+
+	oldObjects, oldIsArray := oldDoc["statements"].([]interface{})
+	newObjects, newIsArray := newDoc["statements"].([]interface{})
+	wholeList := !oldIsArray || !newIsArray
+	var patch Patch
+
+	for i := len(old) - 1; i >= 0; i-- {
+		if _, ok := newIndex[oldKeys[i]]; !ok {
+			patch = append(patch, Operation{Op: "remove", Path: validator.JSONPointer("statements", i)})
+		}
+	}
+	for i, key := range oldKeys {
+		if _, ok := newIndex[key]; !ok {
+			d.add(Change{Kind: Removed, Path: validator.JSONPointer("statements", i), Statement: statementLabel(old[i], i), Old: old[i]})
+		}
+	}
+
+	var current []string
+	for _, key := range oldKeys {
+		if _, ok := newIndex[key]; ok {
+			current = append(current, key)
+		}
+	}
+	for j, key := range newKeys {
+		path := validator.JSONPointer("statements", j)
+		if _, ok := oldIndex[key]; !ok {
+			d.add(Change{Kind: Added, Path: path, Statement: statementLabel(new[j], j), New: new[j]})
+			if !wholeList {
+				patch = append(patch, Operation{Op: "add", Path: path, Value: newObjects[j]})
+			}
+			current = insert(current, j, key)
+			continue
+		}
+		k := j
+		for current[k] != key {
+			k++
+		}
+		if k != j {
+			patch = append(patch, Operation{Op: "move", From: validator.JSONPointer("statements", k), Path: path})
+			current = insert(append(current[:k:k], current[k+1:]...), j, key)
+		}
+	}
+
+	for j, key := range newKeys {
+		i, ok := oldIndex[key]
+		if !ok {
+			continue
+		}
+		var oldObject, newObject map[string]interface{}
+		if !wholeList {
+			oldObject, _ = oldObjects[i].(map[string]interface{})
+			newObject, _ = newObjects[j].(map[string]interface{})
+		}
+		ops, err := d.compareStatement(old[i], new[j], i, j, oldObject, newObject)
+		if err != nil {
+			return err
+		}
+		patch = append(patch, ops...)
+	}
+
+	if wholeList {
+		if !reflect.DeepEqual(oldDoc["statements"], newDoc["statements"]) {
+			d.Patch = append(d.Patch, Operation{Op: "replace", Path: "/statements", Value: newDoc["statements"]})
+		}
+		return nil
+	}
+	d.Patch = append(d.Patch, patch...)
+	return nil
+}
+
+func (d *Diff) compareStatement(old, new policy.Statement, oldIndex, newIndex int, oldObject, newObject map[string]interface{}) (Patch, error) {
+	label := statementLabel(new, newIndex)
+	oldPath := validator.JSONPointer("statements", oldIndex)
+	path := validator.JSONPointer("statements", newIndex)
+	var patch Patch
+
+	if old.Effect != new.Effect {
+		d.add(Change{Kind: Changed, Path: path + "/effect", Statement: label, Field: "effect", Old: old.Effect, New: new.Effect})
+		patch = append(patch, Operation{Op: "replace", Path: path + "/effect", Value: string(new.Effect)})
+	}
+
+	lists := []struct {
+		field, name string
+		old, new    []interface{}
+	}{
+		{"principals", "principal", principalItems(old.Principals), principalItems(new.Principals)},
+		{"actions", "action", actionItems(old.Actions), actionItems(new.Actions)},
+		{"resources", "resource", resourceItems(old.Resources), resourceItems(new.Resources)},
+		{"conditions", "condition", conditionItems(old.Conditions), conditionItems(new.Conditions)},
+	}
+	for _, list := range lists {
+		ops, err := d.compareList(oldPath, path, label, list.field, list.name, list.old, list.new, oldObject, newObject)
+		if err != nil {
+			return nil, err
+		}
+		patch = append(patch, ops...)
+	}
+	return patch, nil
+}
+
+func (d *Diff) compareList(oldPath, path, label, field, name string, old, new []interface{}, oldObject, newObject map[string]interface{}) (Patch, error) {
+	oldKeys, err := itemKeys(old)
+	if err != nil {
+		return nil, fmt.Errorf("statement %s: %v", label, err)
+	}
+	newKeys, err := itemKeys(new)
+	if err != nil {
+		return nil, fmt.Errorf("statement %s: %v", label, err)
+	}
+	oldSet := indexOf(oldKeys)
+	newSet := indexOf(newKeys)
+
+	changed := false
+	for i, key := range oldKeys {
+		if _, ok := newSet[key]; !ok && oldSet[key] == i {
+			changed = true
+			d.add(Change{Kind: Removed, Path: oldPath + validator.JSONPointer(field, i), Statement: label, Field: name, Old: old[i]})
+		}
+	}
+	for j, key := range newKeys {
+		if _, ok := oldSet[key]; !ok && newSet[key] == j {
+			changed = true
+			d.add(Change{Kind: Added, Path: path + validator.JSONPointer(field, j), Statement: label, Field: name, New: new[j]})
+		}
+	}
+	if !changed || oldObject == nil {
+		return nil, nil
+	}
+
+	fieldPath := path + validator.JSONPointer(field)
+	if len(old) == 0 || len(new) == 0 {
+		value, ok := newObject[field]
+		if !ok {
+			return Patch{{Op: "remove", Path: fieldPath}}, nil
+		}
+		return Patch{{Op: "add", Path: fieldPath, Value: value}}, nil
+	}
+
+	var patch Patch
+	for i := len(oldKeys) - 1; i >= 0; i-- {
+		if _, ok := newSet[oldKeys[i]]; !ok {
+			patch = append(patch, Operation{Op: "remove", Path: fieldPath + validator.JSONPointer(i)})
+		}
+	}
+	newValues, _ := newObject[field].([]interface{})
+	for j, key := range newKeys {
+		if _, ok := oldSet[key]; !ok && newSet[key] == j {
+			patch = append(patch, Operation{Op: "add", Path: fieldPath + "/-", Value: newValues[j]})
+		}
+	}
+	return patch, nil
+}
+
+func (d *Diff) add(c Change) {
+	d.Changes = append(d.Changes, c)
+}
+
+// Empty reports whether the policies are semantically identical.
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// String renders one line per change.
+func (d *Diff) String() string {
+	var b strings.Builder
+	for _, c := range d.Changes {
+		switch c.Kind {
+		case Added:
+			b.WriteString("+ ")
+		case Removed:
+			b.WriteString("- ")
+		default:
+			b.WriteString("~ ")
+		}
+		subject := c.Field
+		if c.Statement != "" {
+			subject = strings.TrimSpace("statement " + c.Statement + " " + c.Field)
+		}
+		b.WriteString(subject)
+		if c.Field != "" {
+			switch c.Kind {
+			case Added:
+				b.WriteString(": " + formatValue(c.New))
+			case Removed:
+				b.WriteString(": " + formatValue(c.Old))
+			default:
+				b.WriteString(": " + formatValue(c.Old) + " -> " + formatValue(c.New))
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case policy.Effect:
+		return string(v)
+	case policy.Condition:
+		data, err := json.Marshal(v.Value)
+		if err != nil {
+			return fmt.Sprintf("%s %s %v", v.Operator, v.Key, v.Value)
+		}
+		return fmt.Sprintf("%s %s %s", v.Operator, v.Key, data)
+	case string:
+		return strconv.Quote(v)
+	case policy.Principal, policy.Action, policy.Resource:
+		return strconv.Quote(fmt.Sprint(v))
+	case map[string]interface{}:
+		if data, err := json.Marshal(v); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+func toDocument(p policy.Policy) (map[string]interface{}, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func statementKeys(statements []policy.Statement) []string {
+	keys := make([]string, len(statements))
+	seen := make(map[string]int)
+	for i, s := range statements {
+		key := "#" + strconv.Itoa(i)
+		if s.ID != "" {
+			key = "id:" + s.ID
+			if n := seen[s.ID]; n > 0 {
+				key += "#" + strconv.Itoa(n)
+			}
+			seen[s.ID]++
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+func statementLabel(s policy.Statement, index int) string {
+	if s.ID != "" {
+		return strconv.Quote(s.ID)
+	}
+	return "#" + strconv.Itoa(index)
+}
+
+func indexOf(keys []string) map[string]int {
+	index := make(map[string]int, len(keys))
+	for i, key := range keys {
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+	return index
+}
+
+func insert(keys []string, i int, key string) []string {
+	keys = append(keys, "")
+	copy(keys[i+1:], keys[i:])
+	keys[i] = key
+	return keys
+}
+
+func itemKeys(items []interface{}) ([]string, error) {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		if c, ok := item.(policy.Condition); ok {
+			data, err := c.CanonicalJSON()
+			if err != nil {
+				return nil, fmt.Errorf("condition %d: %v", i, err)
+			}
+			keys[i] = string(data)
+			continue
+		}
+		keys[i] = fmt.Sprint(item)
+	}
+	return keys, nil
+}
+
+func principalItems(values []policy.Principal) []interface{} {
+	items := make([]interface{}, len(values))
+	for i, v := range values {
+		items[i] = v
+	}
+	return items
+}
+
+func actionItems(values []policy.Action) []interface{} {
+	items := make([]interface{}, len(values))
+	for i, v := range values {
+		items[i] = v
+	}
+	return items
+}
+
+func resourceItems(values []policy.Resource) []interface{} {
+	items := make([]interface{}, len(values))
+	for i, v := range values {
+		items[i] = v
+	}
+	return items
+}
+
+func conditionItems(values []policy.Condition) []interface{} {
+	items := make([]interface{}, len(values))
+	for i, v := range values {
+		items[i] = v
+	}
+	return items
+}
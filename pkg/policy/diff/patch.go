@@ -0,0 +1,291 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+// Operation is an RFC 6902 JSON Patch operation.
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON always writes the value of add, replace and test operations.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type Alias Operation
+	switch o.Op {
+	case "add", "replace", "test":
+		return json.Marshal(struct {
+			Op    string      `json:"op"`
+			Path  string      `json:"path"`
+			Value interface{} `json:"value"`
+		}{o.Op, o.Path, o.Value})
+	}
+	return json.Marshal(Alias(o))
+}
+
+type Patch []Operation
+
+// ParsePatch decodes a JSON Patch document, keeping numbers as json.Number.
+func ParsePatch(data []byte) (Patch, error) {
+	var patch Patch
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&patch); err != nil {
+		return nil, err
+	}
+	return patch, nil
+}
+
+// Apply patches the JSON form of p and validates the result with v, the default when nil.
+func Apply(p policy.Policy, patch Patch, v validator.IPolicyValidator) (policy.Policy, error) {
+	doc, err := toDocument(p)
+	if err != nil {
+		return policy.Policy{}, err
+	}
+	var node interface{} = doc
+	for i, op := range patch {
+		if node, err = op.apply(node); err != nil {
+			return policy.Policy{}, fmt.Errorf("patch operation %d (%s %s): %v", i, op.Op, op.Path, err)
+		}
+	}
+
+	data, err := json.Marshal(node)
+	if err != nil {
+		return policy.Policy{}, err
+	}
+	var patched policy.Policy
+	if err := json.Unmarshal(data, &patched); err != nil {
+		return policy.Policy{}, fmt.Errorf("patched policy: %v", err)
+	}
+
+	if v == nil {
+		v = validator.NewDefaultValidator()
+	}
+	if errs := validator.ValidationErrors(v.Validate(patched)); errs.HasErrors() {
+		return policy.Policy{}, errs
+	}
+	return patched, nil
+}
+
+func (o Operation) apply(doc interface{}) (interface{}, error) {
+	tokens, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch o.Op {
+	case "add":
+		return addValue(doc, tokens, clone(o.Value))
+	case "remove":
+		doc, _, err := removeValue(doc, tokens)
+		return doc, err
+	case "replace":
+		if _, err := getValue(doc, tokens); err != nil {
+			return nil, err
+		}
+		value := clone(o.Value)
+		if len(tokens) == 0 {
+			return value, nil
+		}
+		return update(doc, tokens, func(container interface{}, token string) (interface{}, error) {
+			if a, ok := container.([]interface{}); ok {
+				i, _ := arrayIndex(token, len(a))
+				a[i] = value
+				return a, nil
+			}
+			container.(map[string]interface{})[token] = value
+			return container, nil
+		})
+	case "move", "copy":
+		from, err := parsePointer(o.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if o.Op == "move" {
+			if strings.HasPrefix(o.Path, o.From+"/") {
+				return nil, errors.New("cannot move a value into itself")
+			}
+			if doc, _, err = removeValue(doc, from); err != nil {
+				return nil, err
+			}
+		} else {
+			value = clone(value)
+		}
+		return addValue(doc, tokens, value)
+	case "test":
+		value, err := getValue(doc, tokens)
+		if err != nil {
+			return nil, err
+		}
+		if !equalJSON(value, o.Value) {
+			return nil, errors.New("test failed")
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("unknown operation %q", o.Op)
+}
+
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func arrayIndex(token string, length int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || strconv.Itoa(i) != token || i < 0 || i >= length {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	return i, nil
+}
+
+func child(node interface{}, token string) (interface{}, error) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		value, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("member %q not found", token)
+		}
+		return value, nil
+	case []interface{}:
+		i, err := arrayIndex(token, len(n))
+		if err != nil {
+			return nil, err
+		}
+		return n[i], nil
+	}
+	return nil, fmt.Errorf("cannot index %T with %q", node, token)
+}
+
+func getValue(node interface{}, tokens []string) (interface{}, error) {
+	for _, token := range tokens {
+		var err error
+		if node, err = child(node, token); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func update(node interface{}, tokens []string, change func(container interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return change(node, tokens[0])
+	}
+	next, err := child(node, tokens[0])
+	if err != nil {
+		return nil, err
+	}
+	updated, err := update(next, tokens[1:], change)
+	if err != nil {
+		return nil, err
+	}
+	switch n := node.(type) {
+	case map[string]interface{}:
+		n[tokens[0]] = updated
+	case []interface{}:
+		i, _ := arrayIndex(tokens[0], len(n))
+		n[i] = updated
+	}
+	return node, nil
+}
+
+func addValue(doc interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return update(doc, tokens, func(container interface{}, token string) (interface{}, error) {
+		switch c := container.(type) {
+		case map[string]interface{}:
+			c[token] = value
+			return c, nil
+		case []interface{}:
+			i := len(c)
+			if token != "-" {
+				var err error
+				if i, err = arrayIndex(token, len(c)+1); err != nil {
+					return nil, err
+				}
+			}
+			c = append(c, nil)
+			copy(c[i+1:], c[i:])
+			c[i] = value
+			return c, nil
+		}
+		return nil, fmt.Errorf("cannot add %q to %T", token, container)
+	})
+}
+
+func removeValue(doc interface{}, tokens []string) (interface{}, interface{}, error) {
+	if len(tokens) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+	var removed interface{}
+	doc, err := update(doc, tokens, func(container interface{}, token string) (interface{}, error) {
+		value, err := child(container, token)
+		if err != nil {
+			return nil, err
+		}
+		removed = value
+		if a, ok := container.([]interface{}); ok {
+			i, _ := arrayIndex(token, len(a))
+			return append(a[:i:i], a[i+1:]...), nil
+		}
+		delete(container.(map[string]interface{}), token)
+		return container, nil
+	})
+	return doc, removed, err
+}
+
+func clone(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = clone(item)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, item := range v {
+			a[i] = clone(item)
+		}
+		return a
+	}
+	return value
+}
+
+func equalJSON(a, b interface{}) bool {
+	normalize := func(value interface{}) interface{} {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil
+		}
+		var decoded interface{}
+		json.Unmarshal(data, &decoded)
+		return decoded
+	}
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
@@ -5,19 +5,39 @@ import (
 )
 
 type CompositeEvaluator struct {
-	stringEvaluator  *StringEvaluator
-	numericEvaluator *NumericEvaluator
-	dateEvaluator    *DateEvaluator
-	boolEvaluator    *BoolEvaluator
+	stringEvaluator   *StringEvaluator
+	numericEvaluator  *NumericEvaluator
+	dateEvaluator     *DateEvaluator
+	boolEvaluator     *BoolEvaluator
+	scheduleEvaluator *ScheduleEvaluator
+	versionEvaluator  *VersionEvaluator
+	durationEvaluator *DurationEvaluator
+	listEvaluator     *ListEvaluator
+	operators         *OperatorRegistry
+	clock             Clock
 }
 
 func NewCompositeEvaluator() *CompositeEvaluator {
+	return NewCompositeEvaluatorWithClock(NewSystemClock())
+}
+
+func NewCompositeEvaluatorWithClock(clock Clock) *CompositeEvaluator {
+	return NewCompositeEvaluatorWithOperators(clock, NewOperatorRegistry())
+}
+
+func NewCompositeEvaluatorWithOperators(clock Clock, operators *OperatorRegistry) *CompositeEvaluator {
 	patternMatcher := NewRegexPatternMatcher()
 	return &CompositeEvaluator{
-		stringEvaluator:  NewStringEvaluator(patternMatcher),
-		numericEvaluator: NewNumericEvaluator(),
-		dateEvaluator:    NewDateEvaluator(),
-		boolEvaluator:    NewBoolEvaluator(),
+		stringEvaluator:   NewStringEvaluator(patternMatcher),
+		numericEvaluator:  NewNumericEvaluator(),
+		dateEvaluator:     NewDateEvaluatorWithClock(clock),
+		boolEvaluator:     NewBoolEvaluator(),
+		scheduleEvaluator: NewScheduleEvaluator(),
+		versionEvaluator:  NewVersionEvaluator(),
+		durationEvaluator: NewDurationEvaluator(),
+		listEvaluator:     NewListEvaluator(),
+		operators:         operators,
+		clock:             clock,
 	}
 }
 
@@ -25,17 +45,28 @@ func (e *CompositeEvaluator) Evaluate(condition policy.Condition, context map[st
 	key := string(condition.Key)
 	contextValue, exists := context[key]
 	if !exists {
-		return false
+		if key != "" || !condition.Operator.IsSchedule() {
+			return false
+		}
+		contextValue = e.clock.Now()
 	}
 	switch condition.Operator {
 	case policy.StringEquals:
 		return e.stringEvaluator.Equals(contextValue, condition.Value)
 	case policy.StringNotEquals:
 		return e.stringEvaluator.NotEquals(contextValue, condition.Value)
+	case policy.StringEqualsIgnoreCase:
+		return e.stringEvaluator.EqualsIgnoreCase(contextValue, condition.Value)
+	case policy.StringNotEqualsIgnoreCase:
+		return e.stringEvaluator.NotEqualsIgnoreCase(contextValue, condition.Value)
 	case policy.StringLike:
 		return e.stringEvaluator.Like(contextValue, condition.Value)
 	case policy.StringNotLike:
 		return e.stringEvaluator.NotLike(contextValue, condition.Value)
+	case policy.StringMatchesRegex:
+		return e.stringEvaluator.MatchesRegex(contextValue, condition.Value)
+	case policy.StringNotMatchesRegex:
+		return e.stringEvaluator.NotMatchesRegex(contextValue, condition.Value)
 	case policy.NumericEquals:
 		return e.numericEvaluator.Equals(contextValue, condition.Value)
 	case policy.NumericNotEquals:
@@ -62,7 +93,44 @@ func (e *CompositeEvaluator) Evaluate(condition policy.Condition, context map[st
 		return e.dateEvaluator.GreaterThanEquals(contextValue, condition.Value)
 	case policy.Bool:
 		return e.boolEvaluator.Equals(contextValue, condition.Value)
+	case policy.VersionEquals:
+		return e.versionEvaluator.Equals(contextValue, condition.Value)
+	case policy.VersionNotEquals:
+		return e.versionEvaluator.NotEquals(contextValue, condition.Value)
+	case policy.VersionLessThan:
+		return e.versionEvaluator.LessThan(contextValue, condition.Value)
+	case policy.VersionLessThanEquals:
+		return e.versionEvaluator.LessThanEquals(contextValue, condition.Value)
+	case policy.VersionGreaterThan:
+		return e.versionEvaluator.GreaterThan(contextValue, condition.Value)
+	case policy.VersionGreaterThanEquals:
+		return e.versionEvaluator.GreaterThanEquals(contextValue, condition.Value)
+	case policy.DurationEquals:
+		return e.durationEvaluator.Equals(contextValue, condition.Value)
+	case policy.DurationNotEquals:
+		return e.durationEvaluator.NotEquals(contextValue, condition.Value)
+	case policy.DurationLessThan:
+		return e.durationEvaluator.LessThan(contextValue, condition.Value)
+	case policy.DurationLessThanEquals:
+		return e.durationEvaluator.LessThanEquals(contextValue, condition.Value)
+	case policy.DurationGreaterThan:
+		return e.durationEvaluator.GreaterThan(contextValue, condition.Value)
+	case policy.DurationGreaterThanEquals:
+		return e.durationEvaluator.GreaterThanEquals(contextValue, condition.Value)
+	case policy.ScheduleTimeOfDay:
+		return e.scheduleEvaluator.TimeOfDay(contextValue, condition.Value)
+	case policy.ScheduleDayOfWeek:
+		return e.scheduleEvaluator.DayOfWeek(contextValue, condition.Value)
+	case policy.ScheduleCron:
+		return e.scheduleEvaluator.Cron(contextValue, condition.Value)
+	case policy.ListContains:
+		return e.listEvaluator.Contains(contextValue, condition.Value)
+	case policy.ListNotContains:
+		return e.listEvaluator.NotContains(contextValue, condition.Value)
 	default:
+		if custom, ok := e.operators.Lookup(condition.Operator); ok {
+			return custom.Evaluate(contextValue, condition.Value)
+		}
 		return false
 	}
 }
@@ -1,23 +1,33 @@
 package condition
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
 	"strconv"
+	"strings"
 	"time"
 )
 
-type DateEvaluator struct{}
+const epochMillisThreshold = 1e12
+
+type DateEvaluator struct {
+	clock Clock
+}
 
 func NewDateEvaluator() *DateEvaluator {
-	return &DateEvaluator{}
+	return NewDateEvaluatorWithClock(NewSystemClock())
 }
 
-func (e *DateEvaluator) Equals(contextValue, conditionValue interface{}) bool {
-	cv, err1 := toTime(contextValue)
-	cdv, err2 := toTime(conditionValue)
-	if err1 != nil || err2 != nil {
-		return false
+func NewDateEvaluatorWithClock(clock Clock) *DateEvaluator {
+	return &DateEvaluator{
+		clock: clock,
 	}
-	return cv.Equal(cdv)
+}
+
+func (e *DateEvaluator) Equals(contextValue, conditionValue interface{}) bool {
+	c, ok := e.compare(contextValue, conditionValue)
+	return ok && c == 0
 }
 
 func (e *DateEvaluator) NotEquals(contextValue, conditionValue interface{}) bool {
@@ -25,29 +35,142 @@ func (e *DateEvaluator) NotEquals(contextValue, conditionValue interface{}) bool
 }
 
 func (e *DateEvaluator) GreaterThan(contextValue, conditionValue interface{}) bool {
-	cv, err1 := toTime(contextValue)
-	cdv, err2 := toTime(conditionValue)
-	if err1 != nil || err2 != nil {
-		return false
-	}
-	return cv.After(cdv)
+	c, ok := e.compare(contextValue, conditionValue)
+	return ok && c > 0
 }
 
 func (e *DateEvaluator) GreaterThanEquals(contextValue, conditionValue interface{}) bool {
-	return e.GreaterThan(contextValue, conditionValue) || e.Equals(contextValue, conditionValue)
+	c, ok := e.compare(contextValue, conditionValue)
+	return ok && c >= 0
 }
 
 func (e *DateEvaluator) LessThan(contextValue, conditionValue interface{}) bool {
-	cv, err1 := toTime(contextValue)
-	cdv, err2 := toTime(conditionValue)
+	c, ok := e.compare(contextValue, conditionValue)
+	return ok && c < 0
+}
+
+func (e *DateEvaluator) LessThanEquals(contextValue, conditionValue interface{}) bool {
+	c, ok := e.compare(contextValue, conditionValue)
+	return ok && c <= 0
+}
+
+func (e *DateEvaluator) compare(contextValue, conditionValue interface{}) (int, bool) {
+	cv, err1 := ParseAbsoluteTime(contextValue)
+	cdv, err2 := ParseTime(conditionValue, e.clock.Now())
 	if err1 != nil || err2 != nil {
-		return false
+		return 0, false
+	}
+	switch {
+	case cv.Before(cdv):
+		return -1, true
+	case cv.After(cdv):
+		return 1, true
 	}
-	return cv.Before(cdv)
+	return 0, true
 }
 
-func (e *DateEvaluator) LessThanEquals(contextValue, conditionValue interface{}) bool {
-	return e.LessThan(contextValue, conditionValue) || e.Equals(contextValue, conditionValue)
+// ParseAbsoluteTime converts a context value into an instant, rejecting relative expressions.
+func ParseAbsoluteTime(value interface{}) (time.Time, error) {
+	return toTime(value)
+}
+
+// ParseTime converts a condition value into an instant, resolving relative expressions against now.
+func ParseTime(value interface{}, now time.Time) (time.Time, error) {
+	if s, ok := value.(string); ok && IsRelativeTime(s) {
+		return parseRelativeTime(s, now)
+	}
+	return toTime(value)
+}
+
+func IsRelativeTime(expr string) bool {
+	expr = strings.TrimSpace(expr)
+	return strings.HasPrefix(expr, "now") || expr == "today"
+}
+
+func parseRelativeTime(expr string, now time.Time) (time.Time, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "today" {
+		expr = "now/d"
+	}
+	rest := strings.TrimPrefix(expr, "now")
+	result := now
+
+	for len(rest) > 0 && (rest[0] == '+' || rest[0] == '-') {
+		sign := 1
+		if rest[0] == '-' {
+			sign = -1
+		}
+		rest = rest[1:]
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(rest) {
+			return time.Time{}, fmt.Errorf("invalid relative time %q", expr)
+		}
+		amount, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid relative time %q", expr)
+		}
+		if result, err = addTimeUnit(result, sign*amount, rest[i]); err != nil {
+			return time.Time{}, fmt.Errorf("invalid relative time %q: %v", expr, err)
+		}
+		rest = rest[i+1:]
+	}
+
+	if strings.HasPrefix(rest, "/") && len(rest) == 2 {
+		var err error
+		if result, err = truncateTimeUnit(result, rest[1]); err != nil {
+			return time.Time{}, fmt.Errorf("invalid relative time %q: %v", expr, err)
+		}
+		rest = ""
+	}
+	if rest != "" {
+		return time.Time{}, fmt.Errorf("invalid relative time %q", expr)
+	}
+	return result, nil
+}
+
+func addTimeUnit(t time.Time, amount int, unit byte) (time.Time, error) {
+	switch unit {
+	case 's':
+		return t.Add(time.Duration(amount) * time.Second), nil
+	case 'm':
+		return t.Add(time.Duration(amount) * time.Minute), nil
+	case 'h':
+		return t.Add(time.Duration(amount) * time.Hour), nil
+	case 'd':
+		return t.AddDate(0, 0, amount), nil
+	case 'w':
+		return t.AddDate(0, 0, 7*amount), nil
+	case 'M':
+		return t.AddDate(0, amount, 0), nil
+	case 'y':
+		return t.AddDate(amount, 0, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("unknown unit %q", unit)
+}
+
+func truncateTimeUnit(t time.Time, unit byte) (time.Time, error) {
+	year, month, day := t.Date()
+	switch unit {
+	case 's':
+		return t.Truncate(time.Second), nil
+	case 'm':
+		return time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, t.Location()), nil
+	case 'h':
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location()), nil
+	case 'd':
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location()), nil
+	case 'w':
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location()), nil
+	case 'M':
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location()), nil
+	case 'y':
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location()), nil
+	}
+	return time.Time{}, fmt.Errorf("unknown unit %q", unit)
 }
 
 func toTime(value interface{}) (time.Time, error) {
@@ -67,7 +190,50 @@ func toTime(value interface{}) (time.Time, error) {
 				return t, nil
 			}
 		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return fromEpoch(n), nil
+		}
+		if f, err := v.Float64(); err == nil {
+			return fromEpochFloat(f)
+		}
+	case int:
+		return fromEpoch(int64(v)), nil
+	case int32:
+		return fromEpoch(int64(v)), nil
+	case int64:
+		return fromEpoch(v), nil
+	case uint:
+		return fromEpoch(int64(v)), nil
+	case uint32:
+		return fromEpoch(int64(v)), nil
+	case uint64:
+		if v <= math.MaxInt64 {
+			return fromEpoch(int64(v)), nil
+		}
+	case float32:
+		return fromEpochFloat(float64(v))
+	case float64:
+		return fromEpochFloat(v)
 	}
 
 	return time.Time{}, &strconv.NumError{Func: "toTime", Num: "", Err: strconv.ErrSyntax}
 }
+
+func fromEpoch(n int64) time.Time {
+	if n >= epochMillisThreshold || n <= -epochMillisThreshold {
+		return time.UnixMilli(n).UTC()
+	}
+	return time.Unix(n, 0).UTC()
+}
+
+func fromEpochFloat(f float64) (time.Time, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || math.Abs(f) > math.MaxInt64/2 {
+		return time.Time{}, &strconv.NumError{Func: "toTime", Num: "", Err: strconv.ErrRange}
+	}
+	if math.Abs(f) >= epochMillisThreshold {
+		return time.UnixMilli(int64(f)).UTC(), nil
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+}
@@ -0,0 +1,118 @@
+package condition
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	nominalDay   = 24 * time.Hour
+	nominalMonth = 30 * nominalDay
+	nominalYear  = 365 * nominalDay
+)
+
+var iso8601DurationPattern = regexp.MustCompile(
+	`^([-+])?P(?:([0-9.,]+)Y)?(?:([0-9.,]+)M)?(?:([0-9.,]+)W)?(?:([0-9.,]+)D)?(?:T(?:([0-9.,]+)H)?(?:([0-9.,]+)M)?(?:([0-9.,]+)S)?)?$`,
+)
+
+type DurationEvaluator struct{}
+
+func NewDurationEvaluator() *DurationEvaluator {
+	return &DurationEvaluator{}
+}
+
+func (e *DurationEvaluator) Equals(contextValue, conditionValue interface{}) bool {
+	c, ok := compareDurations(contextValue, conditionValue)
+	return ok && c == 0
+}
+
+func (e *DurationEvaluator) NotEquals(contextValue, conditionValue interface{}) bool {
+	return !e.Equals(contextValue, conditionValue)
+}
+
+func (e *DurationEvaluator) LessThan(contextValue, conditionValue interface{}) bool {
+	c, ok := compareDurations(contextValue, conditionValue)
+	return ok && c < 0
+}
+
+func (e *DurationEvaluator) LessThanEquals(contextValue, conditionValue interface{}) bool {
+	c, ok := compareDurations(contextValue, conditionValue)
+	return ok && c <= 0
+}
+
+func (e *DurationEvaluator) GreaterThan(contextValue, conditionValue interface{}) bool {
+	c, ok := compareDurations(contextValue, conditionValue)
+	return ok && c > 0
+}
+
+func (e *DurationEvaluator) GreaterThanEquals(contextValue, conditionValue interface{}) bool {
+	c, ok := compareDurations(contextValue, conditionValue)
+	return ok && c >= 0
+}
+
+// ParseDuration accepts time.Duration values, Go duration strings and ISO 8601 durations.
+func ParseDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		s := strings.TrimSpace(v)
+		if d, err := time.ParseDuration(s); err == nil {
+			return d, nil
+		}
+		return parseISO8601Duration(s)
+	}
+	return 0, fmt.Errorf("unsupported duration value of type %T", value)
+}
+
+func parseISO8601Duration(s string) (time.Duration, error) {
+	match := iso8601DurationPattern.FindStringSubmatch(strings.ToUpper(s))
+	if match == nil || strings.HasSuffix(s, "T") || strings.HasSuffix(strings.ToUpper(s), "P") {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	units := []time.Duration{nominalYear, nominalMonth, 7 * nominalDay, nominalDay, time.Hour, time.Minute, time.Second}
+	var total float64
+	found := false
+	for i, unit := range units {
+		component := match[i+2]
+		if component == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.Replace(component, ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		total += n * float64(unit)
+		found = true
+	}
+	if !found {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("duration %q out of range", s)
+	}
+	if match[1] == "-" {
+		total = -total
+	}
+	return time.Duration(total), nil
+}
+
+func compareDurations(contextValue, conditionValue interface{}) (int, bool) {
+	a, err1 := ParseDuration(contextValue)
+	b, err2 := ParseDuration(conditionValue)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	switch {
+	case a < b:
+		return -1, true
+	case a > b:
+		return 1, true
+	}
+	return 0, true
+}
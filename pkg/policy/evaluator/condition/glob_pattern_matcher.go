@@ -0,0 +1,207 @@
+package condition
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const maxGlobBraceDepth = 8
+
+// GlobPatternMatcher matches with "*", "**", "?", "[a-z]", "[!a-z]" and "{x,y}" globs.
+type GlobPatternMatcher struct {
+	separator string
+}
+
+var globCache = struct {
+	sync.RWMutex
+	patterns map[string]*regexp.Regexp
+}{patterns: make(map[string]*regexp.Regexp)}
+
+func NewGlobPatternMatcher(separator string) *GlobPatternMatcher {
+	return &GlobPatternMatcher{
+		separator: separator,
+	}
+}
+
+func (m *GlobPatternMatcher) MatchesPattern(input, pattern string) bool {
+	regex, err := m.Compile(pattern)
+	if err != nil {
+		return false
+	}
+	return regex.MatchString(input)
+}
+
+func (m *GlobPatternMatcher) Compile(pattern string) (*regexp.Regexp, error) {
+	cacheKey := m.separator + "\x00" + pattern
+	globCache.RLock()
+	regex, ok := globCache.patterns[cacheKey]
+	globCache.RUnlock()
+	if ok {
+		return regex, nil
+	}
+
+	expr, err := m.translate(pattern, 0)
+	if err != nil {
+		return nil, err
+	}
+	regex, err = regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob pattern %q: %v", pattern, err)
+	}
+
+	globCache.Lock()
+	if len(globCache.patterns) >= maxCachedPatterns {
+		globCache.patterns = make(map[string]*regexp.Regexp)
+	}
+	globCache.patterns[cacheKey] = regex
+	globCache.Unlock()
+	return regex, nil
+}
+
+func (m *GlobPatternMatcher) translate(pattern string, depth int) (string, error) {
+	if depth > maxGlobBraceDepth {
+		return "", fmt.Errorf("glob pattern %q nests braces too deeply", pattern)
+	}
+
+	sep := regexp.QuoteMeta(m.separator)
+	segmentChar := "."
+	if m.separator != "" {
+		segmentChar = "[^" + sep + "]"
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				atStart := i == 1 || (m.separator != "" && strings.HasSuffix(pattern[:i-1], m.separator))
+				rest := pattern[i+1:]
+				if m.separator != "" && atStart && strings.HasPrefix(rest, m.separator) {
+					b.WriteString("(?:.*" + sep + ")?")
+					i += len(m.separator)
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString(segmentChar + "*")
+			}
+		case '?':
+			b.WriteString(segmentChar)
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+			if end == 0 {
+				next := strings.IndexByte(pattern[i+2:], ']')
+				if next < 0 {
+					b.WriteString(regexp.QuoteMeta("["))
+					continue
+				}
+				end = next + 1
+			}
+			class := pattern[i+1 : i+1+end]
+			b.WriteString(translateCharClass(class))
+			i += end + 1
+		case '{':
+			end := matchingBrace(pattern, i)
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta("{"))
+				continue
+			}
+			var alternatives []string
+			for _, alternative := range splitAlternatives(pattern[i+1 : end]) {
+				expr, err := m.translate(alternative, depth+1)
+				if err != nil {
+					return "", err
+				}
+				alternatives = append(alternatives, expr)
+			}
+			b.WriteString("(?:" + strings.Join(alternatives, "|") + ")")
+			i = end
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	return b.String(), nil
+}
+
+func translateCharClass(class string) string {
+	negate := false
+	if strings.HasPrefix(class, "!") || strings.HasPrefix(class, "^") {
+		negate = true
+		class = class[1:]
+	}
+
+	var b strings.Builder
+	b.WriteString("[")
+	if negate {
+		b.WriteString("^")
+	}
+	for i := 0; i < len(class); i++ {
+		c := class[i]
+		if c == '-' && i > 0 && i < len(class)-1 {
+			b.WriteByte('-')
+			continue
+		}
+		if c == '\\' && i+1 < len(class) {
+			i++
+			c = class[i]
+		}
+		if strings.IndexByte(`\\[]^-`, c) >= 0 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func matchingBrace(pattern string, start int) int {
+	depth := 0
+	for i := start; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func splitAlternatives(body string) []string {
+	var alternatives []string
+	depth, start := 0, 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				alternatives = append(alternatives, body[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(alternatives, body[start:])
+}
@@ -0,0 +1,82 @@
+package condition
+
+import (
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+const (
+	anyResource     = "*"
+	anySegmentsGlob = "**"
+)
+
+// HierarchicalResourceMatcher matches resource names segment by segment.
+type HierarchicalResourceMatcher struct {
+	segmentMatcher     PatternMatcher
+	syntax             policy.ResourceNameSyntax
+	includeDescendants bool
+}
+
+func NewHierarchicalResourceMatcher(segmentMatcher PatternMatcher, separator string, includeDescendants bool) *HierarchicalResourceMatcher {
+	syntax := policy.ResourceNameSyntax{
+		SegmentSeparator:   separator,
+		QualifierSeparator: policy.DefaultResourceNameSyntax.QualifierSeparator,
+	}
+	return NewHierarchicalResourceMatcherWithSyntax(segmentMatcher, syntax, includeDescendants)
+}
+
+func NewHierarchicalResourceMatcherWithSyntax(segmentMatcher PatternMatcher, syntax policy.ResourceNameSyntax, includeDescendants bool) *HierarchicalResourceMatcher {
+	return &HierarchicalResourceMatcher{
+		segmentMatcher:     segmentMatcher,
+		syntax:             syntax,
+		includeDescendants: includeDescendants,
+	}
+}
+
+func (m *HierarchicalResourceMatcher) MatchesResource(resource, pattern string) bool {
+	if pattern == anyResource {
+		return true
+	}
+	patternName := policy.ParseResourceName(policy.Resource(pattern), m.syntax)
+	resourceName := policy.ParseResourceName(policy.Resource(resource), m.syntax)
+	if m.matchesName(resourceName, patternName) {
+		return true
+	}
+	if m.includeDescendants {
+		for _, ancestor := range resourceName.Ancestors() {
+			if m.matchesName(ancestor, patternName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *HierarchicalResourceMatcher) matchesName(resource, pattern policy.ResourceName) bool {
+	matches := make([][]bool, len(pattern.Segments)+1)
+	for i := range matches {
+		matches[i] = make([]bool, len(resource.Segments)+1)
+	}
+	matches[0][0] = true
+	for i := 1; i <= len(pattern.Segments); i++ {
+		segment := pattern.Segments[i-1]
+		if m.syntax.SegmentSeparator != "" && segment.Type == "" && segment.ID == anySegmentsGlob {
+			for j := 0; j <= len(resource.Segments); j++ {
+				matches[i][j] = matches[i-1][j] || (j > 0 && matches[i][j-1])
+			}
+			continue
+		}
+		for j := 1; j <= len(resource.Segments); j++ {
+			matches[i][j] = matches[i-1][j-1] && m.matchesSegment(resource.Segments[j-1], segment)
+		}
+	}
+	return matches[len(pattern.Segments)][len(resource.Segments)]
+}
+
+func (m *HierarchicalResourceMatcher) matchesSegment(resource, pattern policy.ResourceSegment) bool {
+	if pattern.Type == "" {
+		return m.segmentMatcher.MatchesPattern(resource.String(m.syntax), pattern.ID)
+	}
+	return resource.Type != "" &&
+		m.segmentMatcher.MatchesPattern(resource.Type, pattern.Type) &&
+		m.segmentMatcher.MatchesPattern(resource.ID, pattern.ID)
+}
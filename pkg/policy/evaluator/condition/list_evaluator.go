@@ -0,0 +1,34 @@
+package condition
+
+type ListEvaluator struct{}
+
+func NewListEvaluator() *ListEvaluator {
+	return &ListEvaluator{}
+}
+
+// Contains reports whether the context list has an element equal to the condition value.
+func (e *ListEvaluator) Contains(contextValue, conditionValue interface{}) bool {
+	cdv, ok := conditionValue.(string)
+	if !ok {
+		return false
+	}
+	switch cv := contextValue.(type) {
+	case []string:
+		for _, item := range cv {
+			if item == cdv {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range cv {
+			if s, ok := item.(string); ok && s == cdv {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *ListEvaluator) NotContains(contextValue, conditionValue interface{}) bool {
+	return !e.Contains(contextValue, conditionValue)
+}
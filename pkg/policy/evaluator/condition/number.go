@@ -0,0 +1,164 @@
+package condition
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+type numberKind int
+
+const (
+	intNumber numberKind = iota
+	uintNumber
+	floatNumber
+	decimalNumber
+)
+
+// Number holds a numeric condition or context value without losing precision.
+type Number struct {
+	kind    numberKind
+	i       int64
+	u       uint64
+	f       float64
+	bits    int
+	decimal *big.Rat
+}
+
+func ParseNumber(value interface{}) (Number, error) {
+	if n, ok := value.(json.Number); ok {
+		return parseDecimalString(string(n))
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Number{kind: intNumber, i: v.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Number{kind: uintNumber, u: v.Uint()}, nil
+	case reflect.Float32:
+		return Number{kind: floatNumber, f: v.Float(), bits: 32}, nil
+	case reflect.Float64:
+		return Number{kind: floatNumber, f: v.Float(), bits: 64}, nil
+	case reflect.String:
+		return parseDecimalString(v.String())
+	}
+	return Number{}, fmt.Errorf("unsupported numeric value of type %T", value)
+}
+
+func parseDecimalString(s string) (Number, error) {
+	s = strings.TrimSpace(s)
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Number{kind: intNumber, i: i}, nil
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return Number{kind: uintNumber, u: u}, nil
+	}
+
+	r, err := policy.ParseDecimal(s)
+	if err != nil {
+		return Number{}, err
+	}
+	return Number{kind: decimalNumber, decimal: r}, nil
+}
+
+// Compare returns -1, 0 or 1. The boolean is false when either value is NaN.
+func (n Number) Compare(other Number) (int, bool) {
+	switch {
+	case n.kind == intNumber && other.kind == intNumber:
+		return compareInt64(n.i, other.i), true
+	case n.kind == uintNumber && other.kind == uintNumber:
+		return compareUint(n.u, other.u), true
+	case n.kind == intNumber && other.kind == uintNumber:
+		if n.i < 0 {
+			return -1, true
+		}
+		return compareUint(uint64(n.i), other.u), true
+	case n.kind == uintNumber && other.kind == intNumber:
+		c, ok := other.Compare(n)
+		return -c, ok
+	}
+
+	if n.isNaN() || other.isNaN() {
+		return 0, false
+	}
+	if n.kind == floatNumber && other.kind == floatNumber {
+		return compareFloat64(n.f, other.f), true
+	}
+	if n.isInf() || other.isInf() {
+		return compareFloat64(n.approximate(), other.approximate()), true
+	}
+	return n.rat().Cmp(other.rat()), true
+}
+
+func (n Number) String() string {
+	switch n.kind {
+	case intNumber:
+		return strconv.FormatInt(n.i, 10)
+	case uintNumber:
+		return strconv.FormatUint(n.u, 10)
+	case floatNumber:
+		return strconv.FormatFloat(n.f, 'g', -1, n.bits)
+	}
+	return n.decimal.RatString()
+}
+
+func (n Number) isNaN() bool {
+	return n.kind == floatNumber && math.IsNaN(n.f)
+}
+
+func (n Number) isInf() bool {
+	return n.kind == floatNumber && math.IsInf(n.f, 0)
+}
+
+func (n Number) approximate() float64 {
+	switch n.kind {
+	case intNumber:
+		return float64(n.i)
+	case uintNumber:
+		return float64(n.u)
+	case floatNumber:
+		return n.f
+	}
+	f, _ := n.decimal.Float64()
+	return f
+}
+
+func (n Number) rat() *big.Rat {
+	switch n.kind {
+	case intNumber:
+		return new(big.Rat).SetInt64(n.i)
+	case uintNumber:
+		return new(big.Rat).SetUint64(n.u)
+	case floatNumber:
+		r, _ := new(big.Rat).SetString(strconv.FormatFloat(n.f, 'g', -1, n.bits))
+		return r
+	}
+	return n.decimal
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -1,9 +1,5 @@
 package condition
 
-import (
-	"strconv"
-)
-
 type NumericEvaluator struct{}
 
 func NewNumericEvaluator() *NumericEvaluator {
@@ -11,12 +7,8 @@ func NewNumericEvaluator() *NumericEvaluator {
 }
 
 func (e *NumericEvaluator) Equals(contextValue, conditionValue interface{}) bool {
-	cv, err1 := toFloat64(contextValue)
-	cdv, err2 := toFloat64(conditionValue)
-	if err1 != nil || err2 != nil {
-		return false
-	}
-	return cv == cdv
+	c, ok := compareNumbers(contextValue, conditionValue)
+	return ok && c == 0
 }
 
 func (e *NumericEvaluator) NotEquals(contextValue, conditionValue interface{}) bool {
@@ -24,44 +16,30 @@ func (e *NumericEvaluator) NotEquals(contextValue, conditionValue interface{}) b
 }
 
 func (e *NumericEvaluator) LessThan(contextValue, conditionValue interface{}) bool {
-	cv, err1 := toFloat64(contextValue)
-	cdv, err2 := toFloat64(conditionValue)
-	if err1 != nil || err2 != nil {
-		return false
-	}
-	return cv < cdv
+	c, ok := compareNumbers(contextValue, conditionValue)
+	return ok && c < 0
 }
 
 func (e *NumericEvaluator) LessThanEquals(contextValue, conditionValue interface{}) bool {
-	return e.LessThan(contextValue, conditionValue) || e.Equals(contextValue, conditionValue)
+	c, ok := compareNumbers(contextValue, conditionValue)
+	return ok && c <= 0
 }
 
 func (e *NumericEvaluator) GreaterThan(contextValue, conditionValue interface{}) bool {
-	cv, err1 := toFloat64(contextValue)
-	cdv, err2 := toFloat64(conditionValue)
-	if err1 != nil || err2 != nil {
-		return false
-	}
-	return cv > cdv
+	c, ok := compareNumbers(contextValue, conditionValue)
+	return ok && c > 0
 }
 
 func (e *NumericEvaluator) GreaterThanEquals(contextValue, conditionValue interface{}) bool {
-	return e.GreaterThan(contextValue, conditionValue) || e.Equals(contextValue, conditionValue)
+	c, ok := compareNumbers(contextValue, conditionValue)
+	return ok && c >= 0
 }
 
-func toFloat64(value interface{}) (float64, error) {
-	switch v := value.(type) {
-	case float64:
-		return v, nil
-	case float32:
-		return float64(v), nil
-	case int:
-		return float64(v), nil
-	case int64:
-		return float64(v), nil
-	case string:
-		return strconv.ParseFloat(v, 64)
-	default:
-		return 0, &strconv.NumError{Func: "toFloat64", Num: "", Err: strconv.ErrSyntax}
+func compareNumbers(contextValue, conditionValue interface{}) (int, bool) {
+	cv, err1 := ParseNumber(contextValue)
+	cdv, err2 := ParseNumber(conditionValue)
+	if err1 != nil || err2 != nil {
+		return 0, false
 	}
+	return cv.Compare(cdv)
 }
@@ -0,0 +1,70 @@
+package condition
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+type OperatorFunc func(contextValue, conditionValue interface{}) bool
+
+type ValueValidatorFunc func(conditionValue interface{}) error
+
+type CustomOperator struct {
+	Operator      policy.ConditionOperator
+	Family        policy.OperatorFamily
+	Evaluate      OperatorFunc
+	ValidateValue ValueValidatorFunc
+}
+
+type OperatorRegistry struct {
+	mu        sync.RWMutex
+	operators map[policy.ConditionOperator]CustomOperator
+}
+
+func NewOperatorRegistry() *OperatorRegistry {
+	return &OperatorRegistry{
+		operators: make(map[policy.ConditionOperator]CustomOperator),
+	}
+}
+
+func (r *OperatorRegistry) Register(operator CustomOperator) error {
+	if operator.Operator == "" {
+		return fmt.Errorf("custom operator name is required")
+	}
+	if operator.Evaluate == nil {
+		return fmt.Errorf("custom operator %s must define Evaluate", operator.Operator)
+	}
+	if operator.Operator.IsBuiltin() {
+		return fmt.Errorf("operator %s is built in and cannot be redefined", operator.Operator)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.operators[operator.Operator]; exists {
+		return fmt.Errorf("operator %s is already registered", operator.Operator)
+	}
+	r.operators[operator.Operator] = operator
+	return nil
+}
+
+func (r *OperatorRegistry) Lookup(operator policy.ConditionOperator) (CustomOperator, bool) {
+	if r == nil {
+		return CustomOperator{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	custom, ok := r.operators[operator]
+	return custom, ok
+}
+
+func (r *OperatorRegistry) IsKnown(operator policy.ConditionOperator) bool {
+	if operator.IsBuiltin() {
+		return true
+	}
+	_, ok := r.Lookup(operator)
+	return ok
+}
@@ -0,0 +1,44 @@
+package condition
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+const (
+	MaxRegexLength    = 1024
+	maxCachedPatterns = 4096
+)
+
+var regexCache = struct {
+	sync.RWMutex
+	patterns map[string]*regexp.Regexp
+}{patterns: make(map[string]*regexp.Regexp)}
+
+// CompileRegex compiles and caches an RE2 pattern used by the regex operators.
+func CompileRegex(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > MaxRegexLength {
+		return nil, fmt.Errorf("regular expression exceeds maximum length (%d)", MaxRegexLength)
+	}
+
+	regexCache.RLock()
+	regex, ok := regexCache.patterns[pattern]
+	regexCache.RUnlock()
+	if ok {
+		return regex, nil
+	}
+
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	regexCache.Lock()
+	if len(regexCache.patterns) >= maxCachedPatterns {
+		regexCache.patterns = make(map[string]*regexp.Regexp)
+	}
+	regexCache.patterns[pattern] = regex
+	regexCache.Unlock()
+	return regex, nil
+}
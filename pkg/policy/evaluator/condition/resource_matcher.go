@@ -0,0 +1,5 @@
+package condition
+
+type ResourceMatcher interface {
+	MatchesResource(resource, pattern string) bool
+}
@@ -0,0 +1,396 @@
+package condition
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	timezonePrefix     = "TZ="
+	maxCachedSchedules = 4096
+)
+
+const (
+	allDaysOfMonth uint64 = (1<<32 - 1) &^ 1
+	allDaysOfWeek  uint64 = 1<<7 - 1
+)
+
+var (
+	scheduleCache = struct {
+		sync.Mutex
+		order     *list.List
+		schedules map[string]*list.Element
+	}{order: list.New(), schedules: make(map[string]*list.Element)}
+
+	locationCache sync.Map
+)
+
+type cachedScheduleEntry struct {
+	key      string
+	schedule interface{}
+}
+
+type TimeOfDayRange struct {
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+}
+
+// Contains reports whether t falls in [Start, End), wrapping around midnight.
+func (r TimeOfDayRange) Contains(t time.Time) bool {
+	local := t.In(r.Location)
+	offset := time.Duration(local.Hour())*time.Hour +
+		time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+	if r.Start <= r.End {
+		return offset >= r.Start && offset < r.End
+	}
+	return offset >= r.Start || offset < r.End
+}
+
+type WeekdaySet struct {
+	Days     [7]bool
+	Location *time.Location
+}
+
+func (s WeekdaySet) Contains(t time.Time) bool {
+	return s.Days[t.In(s.Location).Weekday()]
+}
+
+type CronSchedule struct {
+	Minutes     uint64
+	Hours       uint64
+	DaysOfMonth uint64
+	Months      uint64
+	DaysOfWeek  uint64
+	Location    *time.Location
+
+	domRestricted bool
+	dowRestricted bool
+}
+
+// Matches reports whether the minute containing t is selected by the schedule.
+func (s CronSchedule) Matches(t time.Time) bool {
+	local := t.In(s.Location)
+	if !hasBit(s.Minutes, local.Minute()) ||
+		!hasBit(s.Hours, local.Hour()) ||
+		!hasBit(s.Months, int(local.Month())) {
+		return false
+	}
+	domMatch := hasBit(s.DaysOfMonth, local.Day())
+	dowMatch := hasBit(s.DaysOfWeek, int(local.Weekday()))
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+func ParseTimeOfDayRange(value interface{}) (TimeOfDayRange, error) {
+	expr, location, err := scheduleExpression(value, "start", "end")
+	if err != nil {
+		return TimeOfDayRange{}, err
+	}
+	parts := strings.Split(expr, "-")
+	if len(parts) != 2 {
+		return TimeOfDayRange{}, fmt.Errorf("time of day range %q must have the form HH:MM-HH:MM", expr)
+	}
+	start, err := parseTimeOfDay(parts[0])
+	if err != nil {
+		return TimeOfDayRange{}, err
+	}
+	end, err := parseTimeOfDay(parts[1])
+	if err != nil {
+		return TimeOfDayRange{}, err
+	}
+	return TimeOfDayRange{Start: start, End: end, Location: location}, nil
+}
+
+func ParseWeekdaySet(value interface{}) (WeekdaySet, error) {
+	expr, location, err := scheduleExpression(value, "days", "")
+	if err != nil {
+		return WeekdaySet{}, err
+	}
+	set := WeekdaySet{Location: location}
+	for _, item := range strings.Split(expr, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			return WeekdaySet{}, fmt.Errorf("empty weekday in %q", expr)
+		}
+		bounds := strings.SplitN(item, "-", 2)
+		first, err := parseWeekday(bounds[0])
+		if err != nil {
+			return WeekdaySet{}, err
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = parseWeekday(bounds[1]); err != nil {
+				return WeekdaySet{}, err
+			}
+		}
+		for day := first; ; day = (day + 1) % 7 {
+			set.Days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+	return set, nil
+}
+
+func ParseCronSchedule(value interface{}) (CronSchedule, error) {
+	expr, location, err := scheduleExpression(value, "cron", "")
+	if err != nil {
+		return CronSchedule{}, err
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("cron expression %q must have 5 fields", expr)
+	}
+
+	schedule := CronSchedule{Location: location}
+	if schedule.Minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.Hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.DaysOfMonth, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.Months, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return CronSchedule{}, err
+	}
+	if schedule.DaysOfWeek, err = parseCronField(fields[4], 0, 7, weekdayNames); err != nil {
+		return CronSchedule{}, err
+	}
+	if hasBit(schedule.DaysOfWeek, 7) {
+		schedule.DaysOfWeek |= 1
+	}
+	schedule.domRestricted = schedule.DaysOfMonth != allDaysOfMonth
+	schedule.dowRestricted = schedule.DaysOfWeek&allDaysOfWeek != allDaysOfWeek
+	return schedule, nil
+}
+
+func scheduleExpression(value interface{}, key, secondKey string) (string, *time.Location, error) {
+	switch v := value.(type) {
+	case string:
+		expr := strings.TrimSpace(v)
+		zone := ""
+		if strings.HasPrefix(expr, timezonePrefix) {
+			fields := strings.SplitN(expr, " ", 2)
+			zone = strings.TrimPrefix(fields[0], timezonePrefix)
+			expr = ""
+			if len(fields) == 2 {
+				expr = strings.TrimSpace(fields[1])
+			}
+		}
+		location, err := loadLocation(zone)
+		if err != nil {
+			return "", nil, err
+		}
+		if expr == "" {
+			return "", nil, fmt.Errorf("schedule expression is empty")
+		}
+		return expr, location, nil
+	case []string:
+		return scheduleExpression(strings.Join(v, ","), key, secondKey)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		return scheduleExpression(strings.Join(items, ","), key, secondKey)
+	case map[string]string:
+		converted := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			converted[k] = item
+		}
+		return scheduleExpression(converted, key, secondKey)
+	case map[string]interface{}:
+		location, err := loadLocation(stringField(v, "timezone"))
+		if err != nil {
+			return "", nil, err
+		}
+		expr, _, err := scheduleExpression(v[key], key, "")
+		if err != nil {
+			return "", nil, fmt.Errorf("schedule field %q: %v", key, err)
+		}
+		if secondKey != "" {
+			second, _, err := scheduleExpression(v[secondKey], secondKey, "")
+			if err != nil {
+				return "", nil, fmt.Errorf("schedule field %q: %v", secondKey, err)
+			}
+			expr = expr + "-" + second
+		}
+		return expr, location, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported schedule value of type %T", value)
+	}
+}
+
+func stringField(m map[string]interface{}, key string) string {
+	if s, ok := m[key].(string); ok {
+		return s
+	}
+	return ""
+}
+
+func cachedSchedule(kind string, value interface{}, parse func(interface{}) (interface{}, error)) (interface{}, error) {
+	key, ok := value.(string)
+	if !ok {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return parse(value)
+		}
+		key = string(encoded)
+	}
+	key = kind + "\x00" + key
+
+	scheduleCache.Lock()
+	if element, ok := scheduleCache.schedules[key]; ok {
+		scheduleCache.order.MoveToFront(element)
+		scheduleCache.Unlock()
+		return element.Value.(*cachedScheduleEntry).schedule, nil
+	}
+	scheduleCache.Unlock()
+
+	schedule, err := parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleCache.Lock()
+	defer scheduleCache.Unlock()
+	if element, ok := scheduleCache.schedules[key]; ok {
+		scheduleCache.order.MoveToFront(element)
+		return schedule, nil
+	}
+	scheduleCache.schedules[key] = scheduleCache.order.PushFront(&cachedScheduleEntry{key: key, schedule: schedule})
+	if scheduleCache.order.Len() > maxCachedSchedules {
+		oldest := scheduleCache.order.Back()
+		scheduleCache.order.Remove(oldest)
+		delete(scheduleCache.schedules, oldest.Value.(*cachedScheduleEntry).key)
+	}
+	return schedule, nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if location, ok := locationCache.Load(name); ok {
+		return location.(*time.Location), nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	locationCache.Store(name, location)
+	return location, nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return time.Duration(t.Hour())*time.Hour +
+				time.Duration(t.Minute())*time.Minute +
+				time.Duration(t.Second())*time.Second, nil
+		}
+	}
+	if value == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("invalid time of day %q, expected HH:MM or HH:MM:SS", value)
+}
+
+var weekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+func parseWeekday(value string) (int, error) {
+	name := strings.ToLower(strings.TrimSpace(value))
+	if len(name) >= 3 {
+		if day, ok := weekdayNames[name[:3]]; ok && strings.HasPrefix(fullWeekdayName(day), name) {
+			return day, nil
+		}
+	}
+	if day, err := strconv.Atoi(name); err == nil && day >= 0 && day <= 7 {
+		return day % 7, nil
+	}
+	return 0, fmt.Errorf("invalid weekday %q", value)
+}
+
+func fullWeekdayName(day int) string {
+	return strings.ToLower(time.Weekday(day).String())
+}
+
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in cron field %q", field)
+			}
+			part = part[:i]
+		}
+
+		first, last := min, max
+		switch {
+		case part == "*" || part == "?":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if first, err = cronValue(bounds[0], min, max, names); err != nil {
+				return 0, err
+			}
+			if last, err = cronValue(bounds[1], min, max, names); err != nil {
+				return 0, err
+			}
+			if first > last {
+				return 0, fmt.Errorf("invalid range %q in cron field %q", part, field)
+			}
+		default:
+			value, err := cronValue(part, min, max, names)
+			if err != nil {
+				return 0, err
+			}
+			first = value
+			if step == 1 {
+				last = value
+			}
+		}
+
+		for value := first; value <= last; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+func cronValue(value string, min, max int, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("cron value %q out of range [%d-%d]", value, min, max)
+	}
+	return n, nil
+}
+
+func hasBit(bits uint64, n int) bool {
+	return bits&(1<<uint(n)) != 0
+}
@@ -0,0 +1,49 @@
+package condition
+
+type ScheduleEvaluator struct{}
+
+func NewScheduleEvaluator() *ScheduleEvaluator {
+	return &ScheduleEvaluator{}
+}
+
+func (e *ScheduleEvaluator) TimeOfDay(contextValue, conditionValue interface{}) bool {
+	at, err := toTime(contextValue)
+	if err != nil {
+		return false
+	}
+	timeRange, err := cachedSchedule("time_of_day", conditionValue, func(value interface{}) (interface{}, error) {
+		return ParseTimeOfDayRange(value)
+	})
+	if err != nil {
+		return false
+	}
+	return timeRange.(TimeOfDayRange).Contains(at)
+}
+
+func (e *ScheduleEvaluator) DayOfWeek(contextValue, conditionValue interface{}) bool {
+	at, err := toTime(contextValue)
+	if err != nil {
+		return false
+	}
+	days, err := cachedSchedule("day_of_week", conditionValue, func(value interface{}) (interface{}, error) {
+		return ParseWeekdaySet(value)
+	})
+	if err != nil {
+		return false
+	}
+	return days.(WeekdaySet).Contains(at)
+}
+
+func (e *ScheduleEvaluator) Cron(contextValue, conditionValue interface{}) bool {
+	at, err := toTime(contextValue)
+	if err != nil {
+		return false
+	}
+	schedule, err := cachedSchedule("cron", conditionValue, func(value interface{}) (interface{}, error) {
+		return ParseCronSchedule(value)
+	})
+	if err != nil {
+		return false
+	}
+	return schedule.(CronSchedule).Matches(at)
+}
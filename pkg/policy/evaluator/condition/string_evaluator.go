@@ -1,5 +1,9 @@
 package condition
 
+import (
+	"strings"
+)
+
 type StringEvaluator struct {
 	patternMatcher PatternMatcher
 }
@@ -23,6 +27,19 @@ func (e *StringEvaluator) NotEquals(contextValue, conditionValue interface{}) bo
 	return !e.Equals(contextValue, conditionValue)
 }
 
+func (e *StringEvaluator) EqualsIgnoreCase(contextValue, conditionValue interface{}) bool {
+	cv, ok1 := contextValue.(string)
+	cdv, ok2 := conditionValue.(string)
+	if !ok1 || !ok2 {
+		return false
+	}
+	return strings.EqualFold(cv, cdv)
+}
+
+func (e *StringEvaluator) NotEqualsIgnoreCase(contextValue, conditionValue interface{}) bool {
+	return !e.EqualsIgnoreCase(contextValue, conditionValue)
+}
+
 func (e *StringEvaluator) Like(contextValue, conditionValue interface{}) bool {
 	cv, ok1 := contextValue.(string)
 	cdv, ok2 := conditionValue.(string)
@@ -36,3 +53,31 @@ func (e *StringEvaluator) Like(contextValue, conditionValue interface{}) bool {
 func (e *StringEvaluator) NotLike(contextValue, conditionValue interface{}) bool {
 	return !e.Like(contextValue, conditionValue)
 }
+
+func (e *StringEvaluator) MatchesRegex(contextValue, conditionValue interface{}) bool {
+	cv, ok1 := contextValue.(string)
+	cdv, ok2 := conditionValue.(string)
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	regex, err := CompileRegex(cdv)
+	if err != nil {
+		return false
+	}
+	return regex.MatchString(cv)
+}
+
+func (e *StringEvaluator) NotMatchesRegex(contextValue, conditionValue interface{}) bool {
+	cv, ok1 := contextValue.(string)
+	cdv, ok2 := conditionValue.(string)
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	regex, err := CompileRegex(cdv)
+	if err != nil {
+		return false
+	}
+	return !regex.MatchString(cv)
+}
@@ -0,0 +1,148 @@
+package condition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	PreRelease []string
+	Build      string
+}
+
+// ParseVersion parses a SemVer 2.0 version. A leading "v" is tolerated.
+func ParseVersion(value string) (Version, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(value), "v")
+	var v Version
+
+	if i := strings.Index(s, "+"); i >= 0 {
+		v.Build = s[i+1:]
+		if err := validateIdentifiers(v.Build, false); err != nil {
+			return Version{}, fmt.Errorf("invalid build metadata in version %q: %v", value, err)
+		}
+		s = s[:i]
+	}
+	if i := strings.Index(s, "-"); i >= 0 {
+		preRelease := s[i+1:]
+		if err := validateIdentifiers(preRelease, true); err != nil {
+			return Version{}, fmt.Errorf("invalid pre-release in version %q: %v", value, err)
+		}
+		v.PreRelease = strings.Split(preRelease, ".")
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("version %q must have the form MAJOR.MINOR.PATCH", value)
+	}
+	numbers := make([]uint64, 3)
+	for i, part := range parts {
+		n, err := parseNumericIdentifier(part)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %v", value, err)
+		}
+		numbers[i] = n
+	}
+	v.Major, v.Minor, v.Patch = numbers[0], numbers[1], numbers[2]
+	return v, nil
+}
+
+// Compare returns -1, 0 or 1 following SemVer 2.0 precedence.
+func (v Version) Compare(other Version) int {
+	if c := compareUint(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	switch {
+	case len(v.PreRelease) == 0 && len(other.PreRelease) == 0:
+		return 0
+	case len(v.PreRelease) == 0:
+		return 1
+	case len(other.PreRelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.PreRelease) && i < len(other.PreRelease); i++ {
+		if c := comparePreReleaseIdentifier(v.PreRelease[i], other.PreRelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(v.PreRelease)), uint64(len(other.PreRelease)))
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) > 0 {
+		s += "-" + strings.Join(v.PreRelease, ".")
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+func comparePreReleaseIdentifier(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareUint(an, bn)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func parseNumericIdentifier(s string) (uint64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty numeric identifier")
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("numeric identifier %q has a leading zero", s)
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func validateIdentifiers(s string, rejectLeadingZero bool) error {
+	for _, identifier := range strings.Split(s, ".") {
+		if identifier == "" {
+			return fmt.Errorf("empty identifier")
+		}
+		numeric := true
+		for _, r := range identifier {
+			switch {
+			case r >= '0' && r <= '9':
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '-':
+				numeric = false
+			default:
+				return fmt.Errorf("invalid character %q in identifier %q", r, identifier)
+			}
+		}
+		if rejectLeadingZero && numeric && len(identifier) > 1 && identifier[0] == '0' {
+			return fmt.Errorf("numeric identifier %q has a leading zero", identifier)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package condition
+
+type VersionEvaluator struct{}
+
+func NewVersionEvaluator() *VersionEvaluator {
+	return &VersionEvaluator{}
+}
+
+func (e *VersionEvaluator) Equals(contextValue, conditionValue interface{}) bool {
+	c, ok := compareVersions(contextValue, conditionValue)
+	return ok && c == 0
+}
+
+func (e *VersionEvaluator) NotEquals(contextValue, conditionValue interface{}) bool {
+	return !e.Equals(contextValue, conditionValue)
+}
+
+func (e *VersionEvaluator) LessThan(contextValue, conditionValue interface{}) bool {
+	c, ok := compareVersions(contextValue, conditionValue)
+	return ok && c < 0
+}
+
+func (e *VersionEvaluator) LessThanEquals(contextValue, conditionValue interface{}) bool {
+	c, ok := compareVersions(contextValue, conditionValue)
+	return ok && c <= 0
+}
+
+func (e *VersionEvaluator) GreaterThan(contextValue, conditionValue interface{}) bool {
+	c, ok := compareVersions(contextValue, conditionValue)
+	return ok && c > 0
+}
+
+func (e *VersionEvaluator) GreaterThanEquals(contextValue, conditionValue interface{}) bool {
+	c, ok := compareVersions(contextValue, conditionValue)
+	return ok && c >= 0
+}
+
+func compareVersions(contextValue, conditionValue interface{}) (int, bool) {
+	cv, ok1 := contextValue.(string)
+	cdv, ok2 := conditionValue.(string)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	a, err1 := ParseVersion(cv)
+	b, err2 := ParseVersion(cdv)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	return a.Compare(b), true
+}
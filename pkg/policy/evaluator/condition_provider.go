@@ -7,4 +7,6 @@ import (
 type IConditionProvider interface {
 	GetEvaluator() condition.Evaluator
 	GetPatternMatcher() condition.PatternMatcher
+	GetResourceMatcher() condition.ResourceMatcher
+	GetClock() condition.Clock
 }
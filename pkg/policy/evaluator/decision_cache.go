@@ -0,0 +1,330 @@
+package evaluator
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+)
+
+const (
+	DefaultCacheMaxEntries = 10000
+	DefaultCacheTTL        = time.Minute
+)
+
+type CacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Evictions     uint64
+	Expirations   uint64
+	Invalidations uint64
+	Entries       int
+}
+
+func (s CacheStats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// CacheKey identifies a request under one generation of a policy set.
+type CacheKey struct {
+	hash       string
+	scope      *cacheScope
+	generation uint64
+	epoch      uint64
+}
+
+type cacheScope struct {
+	id          uint64
+	contextKeys []string
+	usesClock   bool
+	generation  uint64
+}
+
+type cacheEntry struct {
+	key       string
+	scope     uint64
+	result    Result
+	expiresAt time.Time
+}
+
+type DecisionCache struct {
+	MaxEntries int
+	TTL        time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	scope   *cacheScope
+	scopes  uint64
+	epoch   uint64
+	stats   CacheStats
+	clock   condition.Clock
+}
+
+func NewDecisionCache(maxEntries int, ttl time.Duration) *DecisionCache {
+	return NewDecisionCacheWithClock(maxEntries, ttl, condition.NewSystemClock())
+}
+
+func NewDecisionCacheWithClock(maxEntries int, ttl time.Duration, clock condition.Clock) *DecisionCache {
+	return &DecisionCache{
+		MaxEntries: maxEntries,
+		TTL:        ttl,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		scope:      &cacheScope{},
+		clock:      clock,
+	}
+}
+
+func NewDefaultDecisionCache() *DecisionCache {
+	return NewDecisionCache(DefaultCacheMaxEntries, DefaultCacheTTL)
+}
+
+func (c *DecisionCache) Get(req Request) (Result, bool) {
+	key, ok := c.Key(req)
+	if !ok {
+		c.recordMiss()
+		return Result{}, false
+	}
+	return c.GetKey(key)
+}
+
+func (c *DecisionCache) Put(req Request, result Result) {
+	if key, ok := c.Key(req); ok {
+		c.PutKey(key, result)
+	}
+}
+
+// GetKey looks up a decision by a key from Key. Stale keys always miss.
+func (c *DecisionCache) GetKey(key CacheKey) (Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stale(key) {
+		c.stats.Misses++
+		return Result{}, false
+	}
+	element, exists := c.entries[key.hash]
+	if !exists {
+		c.stats.Misses++
+		return Result{}, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if c.TTL > 0 && !c.clock.Now().Before(entry.expiresAt) {
+		c.removeElement(element)
+		c.stats.Expirations++
+		c.stats.Misses++
+		return Result{}, false
+	}
+
+	c.lru.MoveToFront(element)
+	c.stats.Hits++
+	return copyResult(entry.result), true
+}
+
+// PutKey stores a decision under a key from Key. Stale keys are dropped.
+func (c *DecisionCache) PutKey(key CacheKey, result Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stale(key) {
+		return
+	}
+	entry := &cacheEntry{
+		key:       key.hash,
+		scope:     key.scope.id,
+		result:    copyResult(result),
+		expiresAt: c.clock.Now().Add(c.TTL),
+	}
+	if element, exists := c.entries[key.hash]; exists {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[key.hash] = c.lru.PushFront(entry)
+	for c.MaxEntries > 0 && c.lru.Len() > c.MaxEntries {
+		c.removeElement(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// Invalidate drops every cached decision and rekeys by the policies' context keys.
+func (c *DecisionCache) Invalidate(policies []policy.Policy) {
+	keys := conditionKeys(policies)
+	clockDependent := usesClock(policies)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	c.epoch++
+	c.reset(c.scope, keys, clockDependent)
+}
+
+func (c *DecisionCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// Key computes the cache key of req, reporting false when it cannot be cached.
+func (c *DecisionCache) Key(req Request) (CacheKey, bool) {
+	return c.keyIn(c.scope, req)
+}
+
+func (c *DecisionCache) attach() *cacheScope {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scopes++
+	return &cacheScope{id: c.scopes}
+}
+
+func (c *DecisionCache) invalidateScope(scope *cacheScope, policies []policy.Policy) {
+	keys := conditionKeys(policies)
+	clockDependent := usesClock(policies)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for element := c.lru.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*cacheEntry).scope == scope.id {
+			c.removeElement(element)
+		}
+		element = next
+	}
+	c.reset(scope, keys, clockDependent)
+}
+
+func (c *DecisionCache) reset(scope *cacheScope, keys []string, clockDependent bool) {
+	scope.contextKeys = keys
+	scope.usesClock = clockDependent
+	scope.generation++
+	c.stats.Invalidations++
+}
+
+func (c *DecisionCache) stale(key CacheKey) bool {
+	return key.scope == nil || key.generation != key.scope.generation || key.epoch != c.epoch
+}
+
+func (c *DecisionCache) recordMiss() {
+	c.mu.Lock()
+	c.stats.Misses++
+	c.mu.Unlock()
+}
+
+func (c *DecisionCache) removeElement(element *list.Element) {
+	entry := element.Value.(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.lru.Remove(element)
+}
+
+func (c *DecisionCache) keyIn(scope *cacheScope, req Request) (CacheKey, bool) {
+	c.mu.Lock()
+	contextKeys := scope.contextKeys
+	clockDependent := scope.usesClock
+	generation := scope.generation
+	epoch := c.epoch
+	c.mu.Unlock()
+
+	if clockDependent {
+		return CacheKey{}, false
+	}
+
+	h := sha256.New()
+	var header [24]byte
+	binary.BigEndian.PutUint64(header[:8], scope.id)
+	binary.BigEndian.PutUint64(header[8:16], generation)
+	binary.BigEndian.PutUint64(header[16:], epoch)
+	h.Write(header[:])
+	writeField(h, []byte(req.Principal))
+	writeField(h, []byte(req.Action))
+	writeField(h, []byte(req.Resource))
+	for _, key := range contextKeys {
+		value, exists := req.Context[key]
+		if !exists {
+			writeField(h, nil)
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return CacheKey{}, false
+		}
+		writeField(h, []byte(key))
+		writeField(h, encoded)
+	}
+	key := CacheKey{hash: hex.EncodeToString(h.Sum(nil)), scope: scope, generation: generation, epoch: epoch}
+	return key, true
+}
+
+func writeField(h hash.Hash, data []byte) {
+	var length [8]byte
+	if data == nil {
+		binary.BigEndian.PutUint64(length[:], ^uint64(0))
+		h.Write(length[:])
+		return
+	}
+	binary.BigEndian.PutUint64(length[:], uint64(len(data)))
+	h.Write(length[:])
+	h.Write(data)
+}
+
+func conditionKeys(policies []policy.Policy) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, p := range policies {
+		for _, statement := range p.Statements {
+			for _, condition := range statement.Conditions {
+				key := string(condition.Key)
+				if !seen[key] {
+					seen[key] = true
+					keys = append(keys, key)
+				}
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func usesClock(policies []policy.Policy) bool {
+	for _, p := range policies {
+		for _, statement := range p.Statements {
+			for _, c := range statement.Conditions {
+				if c.Operator.IsSchedule() && c.Key == "" {
+					return true
+				}
+				family, _ := c.Operator.Family()
+				if value, ok := c.Value.(string); ok && family == policy.DateFamily && condition.IsRelativeTime(value) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func copyResult(result Result) Result {
+	if result.MatchedRules != nil {
+		result.MatchedRules = append([]string(nil), result.MatchedRules...)
+	}
+	return result
+}
@@ -1,13 +1,21 @@
 package evaluator
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/schema"
 )
 
 type DefaultPolicyEvaluator struct {
+	mu                sync.RWMutex
 	policies          []policy.Policy
 	conditionProvider IConditionProvider
 	policyMatcher     *PolicyMatcher
+	decisionCache     *DecisionCache
+	cacheScope        *cacheScope
+	contextSchema     *schema.ContextSchema
 }
 
 func NewDefaultEvaluator(conditionProvider IConditionProvider, policies ...policy.Policy) *DefaultPolicyEvaluator {
@@ -18,10 +26,70 @@ func NewDefaultEvaluator(conditionProvider IConditionProvider, policies ...polic
 	}
 }
 
+func NewCachedEvaluator(conditionProvider IConditionProvider, cache *DecisionCache, policies ...policy.Policy) *DefaultPolicyEvaluator {
+	e := NewDefaultEvaluator(conditionProvider, policies...)
+	e.decisionCache = cache
+	if cache != nil {
+		e.cacheScope = cache.attach()
+		cache.invalidateScope(e.cacheScope, e.policies)
+	}
+	return e
+}
+
 func (e *DefaultPolicyEvaluator) AddPolicy(policy policy.Policy) {
-	e.policies = append(e.policies, policy)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.policies = append(e.policies[:len(e.policies):len(e.policies)], policy)
+	if e.decisionCache != nil {
+		e.decisionCache.invalidateScope(e.cacheScope, e.policies)
+	}
+}
+
+func (e *DefaultPolicyEvaluator) currentPolicies() []policy.Policy {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.policies
+}
+
+// SetContextSchema makes Evaluate validate and coerce request contexts before matching.
+func (e *DefaultPolicyEvaluator) SetContextSchema(contextSchema *schema.ContextSchema) {
+	e.contextSchema = contextSchema
 }
 
 func (e *DefaultPolicyEvaluator) Evaluate(req Request) Result {
-	return e.policyMatcher.MatchPolicy(req, e.policies)
+	if e.contextSchema != nil {
+		context, err := e.contextSchema.Coerce(req.Context)
+		if err != nil {
+			return Result{
+				Allowed:     false,
+				Reason:      fmt.Sprintf("Invalid request context: %v", err),
+				EvaluatedAt: e.conditionProvider.GetClock().Now(),
+				Err:         err,
+			}
+		}
+		req.Context = context
+	}
+	if e.decisionCache == nil {
+		return e.policyMatcher.MatchPolicy(req, e.currentPolicies())
+	}
+	key, cacheable := e.decisionCache.keyIn(e.cacheScope, req)
+	if !cacheable {
+		e.decisionCache.recordMiss()
+		return e.policyMatcher.MatchPolicy(req, e.currentPolicies())
+	}
+	if result, ok := e.decisionCache.GetKey(key); ok {
+		result.EvaluatedAt = e.conditionProvider.GetClock().Now()
+		return result
+	}
+	result := e.policyMatcher.MatchPolicy(req, e.currentPolicies())
+	e.decisionCache.PutKey(key, result)
+	return result
+}
+
+func (e *DefaultPolicyEvaluator) CacheStats() (CacheStats, bool) {
+	if e.decisionCache == nil {
+		return CacheStats{}, false
+	}
+	return e.decisionCache.Stats(), true
 }
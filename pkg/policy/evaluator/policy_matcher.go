@@ -2,7 +2,6 @@ package evaluator
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/CarlosHe/go-policy-management/pkg/policy"
 )
@@ -20,7 +19,7 @@ func NewPolicyMatcher(conditionProvider IConditionProvider) *PolicyMatcher {
 func (m *PolicyMatcher) MatchPolicy(req Request, policies []policy.Policy) Result {
 	result := Result{
 		Allowed:     false,
-		EvaluatedAt: time.Now(),
+		EvaluatedAt: m.conditionProvider.GetClock().Now(),
 	}
 	if len(policies) == 0 {
 		result.Reason = "No policies defined"
@@ -47,6 +46,19 @@ func (m *PolicyMatcher) MatchPolicy(req Request, policies []policy.Policy) Resul
 }
 
 func (m *PolicyMatcher) matchStatement(req Request, statement policy.Statement, policyID string) bool {
+	if len(statement.Principals) > 0 {
+		principalMatched := false
+		for _, principal := range statement.Principals {
+			if m.conditionProvider.GetPatternMatcher().MatchesPattern(req.Principal, string(principal)) {
+				principalMatched = true
+				break
+			}
+		}
+		if !principalMatched {
+			return false
+		}
+	}
+
 	actionMatched := false
 	for _, action := range statement.Actions {
 		if m.conditionProvider.GetPatternMatcher().MatchesPattern(string(req.Action), string(action)) {
@@ -60,7 +72,7 @@ func (m *PolicyMatcher) matchStatement(req Request, statement policy.Statement,
 
 	resourceMatched := false
 	for _, resource := range statement.Resources {
-		if m.conditionProvider.GetPatternMatcher().MatchesPattern(string(req.Resource), string(resource)) {
+		if m.conditionProvider.GetResourceMatcher().MatchesResource(string(req.Resource), string(resource)) {
 			resourceMatched = true
 			break
 		}
@@ -9,4 +9,6 @@ type Result struct {
 	Reason       string
 	EvaluatedAt  time.Time
 	MatchedRules []string
+	// Err is set when the request could not be evaluated.
+	Err error
 }
@@ -1,32 +1,66 @@
 package factory
 
 import (
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
 	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
 )
 
 type IConditionFactory interface {
 	CreateEvaluator() condition.Evaluator
 	CreatePatternMatcher() condition.PatternMatcher
+	CreateResourceMatcher() condition.ResourceMatcher
 	CreateStringEvaluator() *condition.StringEvaluator
 	CreateNumericEvaluator() *condition.NumericEvaluator
 	CreateDateEvaluator() *condition.DateEvaluator
 	CreateBoolEvaluator() *condition.BoolEvaluator
+	CreateScheduleEvaluator() *condition.ScheduleEvaluator
+	CreateVersionEvaluator() *condition.VersionEvaluator
+	CreateDurationEvaluator() *condition.DurationEvaluator
+	CreateClock() condition.Clock
 }
 
-type DefaultConditionFactory struct{}
+type DefaultConditionFactory struct {
+	ExtendedGlob       bool
+	GlobSeparator      string
+	ResourceSeparator  string
+	QualifierSeparator string
+	IncludeDescendants bool
+	Operators          *condition.OperatorRegistry
+	clock              condition.Clock
+}
 
 func NewConditionFactory() *DefaultConditionFactory {
-	return &DefaultConditionFactory{}
+	return NewConditionFactoryWithClock(condition.NewSystemClock())
+}
+
+func NewConditionFactoryWithClock(clock condition.Clock) *DefaultConditionFactory {
+	return &DefaultConditionFactory{
+		ExtendedGlob:       false,
+		GlobSeparator:      "/",
+		ResourceSeparator:  policy.DefaultResourceNameSyntax.SegmentSeparator,
+		QualifierSeparator: policy.DefaultResourceNameSyntax.QualifierSeparator,
+		IncludeDescendants: false,
+		Operators:          condition.NewOperatorRegistry(),
+		clock:              clock,
+	}
 }
 
 func (f *DefaultConditionFactory) CreateEvaluator() condition.Evaluator {
-	return condition.NewCompositeEvaluator()
+	return condition.NewCompositeEvaluatorWithOperators(f.CreateClock(), f.Operators)
 }
 
 func (f *DefaultConditionFactory) CreatePatternMatcher() condition.PatternMatcher {
+	if f.ExtendedGlob {
+		return condition.NewGlobPatternMatcher(f.GlobSeparator)
+	}
 	return condition.NewRegexPatternMatcher()
 }
 
+func (f *DefaultConditionFactory) CreateResourceMatcher() condition.ResourceMatcher {
+	syntax := policy.ResourceNameSyntax{SegmentSeparator: f.ResourceSeparator, QualifierSeparator: f.QualifierSeparator}
+	return condition.NewHierarchicalResourceMatcherWithSyntax(f.CreatePatternMatcher(), syntax, f.IncludeDescendants)
+}
+
 func (f *DefaultConditionFactory) CreateStringEvaluator() *condition.StringEvaluator {
 	return condition.NewStringEvaluator(f.CreatePatternMatcher())
 }
@@ -36,9 +70,28 @@ func (f *DefaultConditionFactory) CreateNumericEvaluator() *condition.NumericEva
 }
 
 func (f *DefaultConditionFactory) CreateDateEvaluator() *condition.DateEvaluator {
-	return condition.NewDateEvaluator()
+	return condition.NewDateEvaluatorWithClock(f.CreateClock())
 }
 
 func (f *DefaultConditionFactory) CreateBoolEvaluator() *condition.BoolEvaluator {
 	return condition.NewBoolEvaluator()
 }
+
+func (f *DefaultConditionFactory) CreateScheduleEvaluator() *condition.ScheduleEvaluator {
+	return condition.NewScheduleEvaluator()
+}
+
+func (f *DefaultConditionFactory) CreateVersionEvaluator() *condition.VersionEvaluator {
+	return condition.NewVersionEvaluator()
+}
+
+func (f *DefaultConditionFactory) CreateDurationEvaluator() *condition.DurationEvaluator {
+	return condition.NewDurationEvaluator()
+}
+
+func (f *DefaultConditionFactory) CreateClock() condition.Clock {
+	if f.clock == nil {
+		return condition.NewSystemClock()
+	}
+	return f.clock
+}
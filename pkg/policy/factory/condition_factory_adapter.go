@@ -21,3 +21,11 @@ func (a *ConditionFactoryAdapter) GetEvaluator() condition.Evaluator {
 func (a *ConditionFactoryAdapter) GetPatternMatcher() condition.PatternMatcher {
 	return a.factory.CreatePatternMatcher()
 }
+
+func (a *ConditionFactoryAdapter) GetResourceMatcher() condition.ResourceMatcher {
+	return a.factory.CreateResourceMatcher()
+}
+
+func (a *ConditionFactoryAdapter) GetClock() condition.Clock {
+	return a.factory.CreateClock()
+}
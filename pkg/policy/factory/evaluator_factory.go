@@ -3,23 +3,41 @@ package factory
 import (
 	"github.com/CarlosHe/go-policy-management/pkg/policy"
 	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/schema"
 )
 
 type IEvaluatorFactory interface {
 	CreatePolicyEvaluator(policies ...policy.Policy) evaluator.IPolicyEvaluator
+	CreateCachedPolicyEvaluator(cache *evaluator.DecisionCache, policies ...policy.Policy) evaluator.IPolicyEvaluator
 }
 
 type DefaultEvaluatorFactory struct {
+	// ContextSchema, when set, validates and coerces request contexts.
+	ContextSchema *schema.ContextSchema
+
 	conditionFactory IConditionFactory
 }
 
 func NewEvaluatorFactory() *DefaultEvaluatorFactory {
+	return NewEvaluatorFactoryWithConditionFactory(NewConditionFactory())
+}
+
+func NewEvaluatorFactoryWithConditionFactory(conditionFactory IConditionFactory) *DefaultEvaluatorFactory {
 	return &DefaultEvaluatorFactory{
-		conditionFactory: NewConditionFactory(),
+		conditionFactory: conditionFactory,
 	}
 }
 
 func (f *DefaultEvaluatorFactory) CreatePolicyEvaluator(policies ...policy.Policy) evaluator.IPolicyEvaluator {
 	adapter := NewConditionFactoryAdapter(f.conditionFactory)
-	return evaluator.NewDefaultEvaluator(adapter, policies...)
+	e := evaluator.NewDefaultEvaluator(adapter, policies...)
+	e.SetContextSchema(f.ContextSchema)
+	return e
+}
+
+func (f *DefaultEvaluatorFactory) CreateCachedPolicyEvaluator(cache *evaluator.DecisionCache, policies ...policy.Policy) evaluator.IPolicyEvaluator {
+	adapter := NewConditionFactoryAdapter(f.conditionFactory)
+	e := evaluator.NewCachedEvaluator(adapter, cache, policies...)
+	e.SetContextSchema(f.ContextSchema)
+	return e
 }
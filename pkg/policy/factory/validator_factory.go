@@ -1,24 +1,61 @@
 package factory
 
 import (
+	"github.com/CarlosHe/go-policy-management/pkg/policy/catalog"
 	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
 )
 
 type IValidatorFactory interface {
 	CreatePolicyValidator() validator.IPolicyValidator
 	CreateFullValidator() validator.IFullValidatorInterface
+	CreatePolicySetValidator() *validator.PolicySetValidator
 }
 
-type DefaultValidatorFactory struct{}
+type DefaultValidatorFactory struct {
+	// Catalog, when set, makes created validators reject undeclared names.
+	Catalog *catalog.Catalog
 
-func NewValidatorFactory() *DefaultValidatorFactory {
-	return &DefaultValidatorFactory{}
+	options []validator.Option
+}
+
+func NewValidatorFactory(opts ...validator.Option) *DefaultValidatorFactory {
+	return &DefaultValidatorFactory{
+		options: opts,
+	}
+}
+
+func NewValidatorFactoryWithCatalog(cat *catalog.Catalog, opts ...validator.Option) *DefaultValidatorFactory {
+	return &DefaultValidatorFactory{
+		Catalog: cat,
+		options: opts,
+	}
+}
+
+// NewValidatorFactoryFromConfig creates a factory from a JSON validator config.
+func NewValidatorFactoryFromConfig(data []byte, opts ...validator.Option) (*DefaultValidatorFactory, error) {
+	config, err := validator.LoadValidatorConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewValidatorFactory(append([]validator.Option{validator.WithConfig(config)}, opts...)...), nil
 }
 
 func (f *DefaultValidatorFactory) CreatePolicyValidator() validator.IPolicyValidator {
-	return validator.NewDefaultValidator()
+	return f.createValidator()
 }
 
 func (f *DefaultValidatorFactory) CreateFullValidator() validator.IFullValidatorInterface {
-	return validator.NewDefaultValidator()
+	return f.createValidator()
+}
+
+func (f *DefaultValidatorFactory) CreatePolicySetValidator() *validator.PolicySetValidator {
+	return validator.NewPolicySetValidator(f.createValidator())
+}
+
+func (f *DefaultValidatorFactory) createValidator() *validator.DefaultValidator {
+	opts := f.options
+	if f.Catalog != nil {
+		opts = append([]validator.Option{validator.WithCatalog(f.Catalog)}, opts...)
+	}
+	return validator.NewValidator(opts...)
 }
@@ -0,0 +1,110 @@
+package iam
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+const DocumentVersion = "2012-10-17"
+
+// Document is an AWS IAM policy document.
+type Document struct {
+	Version   string        `json:"Version"`
+	ID        string        `json:"Id,omitempty"`
+	Statement StatementList `json:"Statement"`
+}
+
+type Statement struct {
+	Sid          string          `json:"Sid,omitempty"`
+	Effect       string          `json:"Effect"`
+	Principal    json.RawMessage `json:"Principal,omitempty"`
+	NotPrincipal json.RawMessage `json:"NotPrincipal,omitempty"`
+	Action       StringList      `json:"Action,omitempty"`
+	NotAction    StringList      `json:"NotAction,omitempty"`
+	Resource     StringList      `json:"Resource,omitempty"`
+	NotResource  StringList      `json:"NotResource,omitempty"`
+	// Condition maps an operator to condition keys and their values.
+	Condition map[string]map[string]ValueList `json:"Condition,omitempty"`
+}
+
+// StatementList accepts both a single statement object and an array.
+type StatementList []Statement
+
+func (l *StatementList) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var statement Statement
+		if err := json.Unmarshal(data, &statement); err != nil {
+			return err
+		}
+		*l = StatementList{statement}
+		return nil
+	}
+	var statements []Statement
+	if err := json.Unmarshal(data, &statements); err != nil {
+		return err
+	}
+	*l = statements
+	return nil
+}
+
+// StringList accepts a single string or an array of strings.
+type StringList []string
+
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = StringList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("expected a string or an array of strings")
+	}
+	*l = list
+	return nil
+}
+
+func (l StringList) MarshalJSON() ([]byte, error) {
+	if len(l) == 1 {
+		return json.Marshal(l[0])
+	}
+	return json.Marshal([]string(l))
+}
+
+// ValueList holds the values of one condition key.
+type ValueList []interface{}
+
+func (l *ValueList) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := decodeJSON(data, &value); err != nil {
+		return err
+	}
+	if list, ok := value.([]interface{}); ok {
+		*l = list
+		return nil
+	}
+	*l = ValueList{value}
+	return nil
+}
+
+func (l ValueList) MarshalJSON() ([]byte, error) {
+	if len(l) == 1 {
+		return json.Marshal(l[0])
+	}
+	return json.Marshal([]interface{}(l))
+}
+
+func ParseDocument(data []byte) (Document, error) {
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Document{}, fmt.Errorf("invalid IAM policy document: %v", err)
+	}
+	return doc, nil
+}
+
+func decodeJSON(data []byte, target interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
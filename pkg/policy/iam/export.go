@@ -0,0 +1,124 @@
+package iam
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/convert"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+var invalidSidPattern = regexp.MustCompile(`[^A-Za-z0-9]`)
+
+type ExportOptions struct {
+	// SkipUnsupported drops unsupported statements instead of failing.
+	SkipUnsupported bool
+}
+
+// Export converts a policy into an indented IAM policy document.
+func Export(p policy.Policy, opts ExportOptions) ([]byte, *convert.Report, error) {
+	doc, report, err := ExportDocument(p, opts)
+	if err != nil {
+		return nil, report, err
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, report, err
+	}
+	return data, report, nil
+}
+
+func ExportDocument(p policy.Policy, opts ExportOptions) (Document, *convert.Report, error) {
+	report := &convert.Report{}
+	doc := Document{Version: DocumentVersion, ID: p.ID}
+
+	for i, statement := range p.Statements {
+		if s, ok := exportStatement(statement, validator.JSONPointer("statements", i), report); ok {
+			doc.Statement = append(doc.Statement, s)
+		}
+	}
+
+	if len(report.Unsupported()) > 0 && !opts.SkipUnsupported {
+		return Document{}, report, &convert.ConversionError{Report: report}
+	}
+	return doc, report, nil
+}
+
+func exportStatement(s policy.Statement, path string, report *convert.Report) (Statement, bool) {
+	before := len(report.Unsupported())
+
+	statement := Statement{
+		Sid:    s.ID,
+		Effect: string(s.Effect),
+	}
+	if invalidSidPattern.MatchString(s.ID) {
+		statement.Sid = invalidSidPattern.ReplaceAllString(s.ID, "")
+		report.Warnf(path+"/id", "statement ID %q renamed to Sid %q, IAM only allows alphanumeric characters", s.ID, statement.Sid)
+	}
+	if len(s.Principals) > 0 {
+		report.Unsupportedf(path+"/principals", "statement principals have no equivalent in an identity policy")
+	}
+	for _, action := range s.Actions {
+		statement.Action = append(statement.Action, string(action))
+	}
+	for i, resource := range s.Resources {
+		resourcePath := path + validator.JSONPointer("resources", i)
+		pattern, widened, ok := exportResource(resource)
+		if !ok {
+			report.Unsupportedf(resourcePath, "%q has no IAM equivalent, \"**\" is only supported as a trailing \"*/**\"", resource)
+			continue
+		}
+		if widened {
+			report.Warnf(resourcePath, "%q matches more in IAM, where wildcards also match %q", pattern, resourceSeparator)
+		}
+		statement.Resource = append(statement.Resource, pattern)
+	}
+
+	for i, condition := range s.Conditions {
+		conditionPath := path + validator.JSONPointer("conditions", i)
+		name, ok := iamOperatorName(condition.Operator)
+		if !ok {
+			report.Unsupportedf(conditionPath+"/operator", "condition operator %q has no IAM equivalent", condition.Operator)
+			continue
+		}
+		if negatedOperators[condition.Operator] {
+			report.Unsupportedf(conditionPath+"/operator", "%s is false for a missing key here but true in IAM", name)
+			continue
+		}
+		value, ok := exportValue(condition.Value, conditionPath+"/value", report)
+		if !ok {
+			continue
+		}
+
+		if statement.Condition == nil {
+			statement.Condition = make(map[string]map[string]ValueList)
+		}
+		if statement.Condition[name] == nil {
+			statement.Condition[name] = make(map[string]ValueList)
+		}
+		key := string(condition.Key)
+		values, exists := statement.Condition[name][key]
+		if exists {
+			report.Unsupportedf(conditionPath, "%s is applied to %q more than once", name, key)
+			continue
+		}
+		statement.Condition[name][key] = append(values, value)
+	}
+
+	return statement, len(report.Unsupported()) == before
+}
+
+func exportValue(value interface{}, path string, report *convert.Report) (interface{}, bool) {
+	switch v := value.(type) {
+	case string, json.Number:
+		return v, true
+	case bool:
+		return fmt.Sprint(v), true
+	case int, int32, int64, uint, uint32, uint64, float32, float64:
+		return json.Number(fmt.Sprint(v)), true
+	}
+	report.Unsupportedf(path, "condition value of type %T has no IAM equivalent", value)
+	return nil, false
+}
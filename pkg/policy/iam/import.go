@@ -0,0 +1,237 @@
+package iam
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/convert"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+const maxExpandedStatements = 64
+
+type ImportOptions struct {
+	// ID and Name are used for the resulting policy; ID defaults to the document Id.
+	ID   string
+	Name string
+	// CreatedAt defaults to the current time.
+	CreatedAt time.Time
+	// SkipUnsupported drops unsupported statements instead of failing.
+	SkipUnsupported bool
+	// AllowNarrowedDeny imports Deny statements whose inner wildcards deny less than in IAM.
+	AllowNarrowedDeny bool
+}
+
+// Import converts an IAM policy document into a policy.
+func Import(data []byte, opts ImportOptions) (policy.Policy, *convert.Report, error) {
+	doc, err := ParseDocument(data)
+	if err != nil {
+		return policy.Policy{}, nil, err
+	}
+	return ImportDocument(doc, opts)
+}
+
+func ImportDocument(doc Document, opts ImportOptions) (policy.Policy, *convert.Report, error) {
+	report := &convert.Report{}
+
+	p := policy.Policy{
+		Version:   policy.PolicyVersion,
+		ID:        opts.ID,
+		Name:      opts.Name,
+		CreatedAt: opts.CreatedAt,
+	}
+	if p.ID == "" {
+		p.ID = doc.ID
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now()
+	}
+
+	if doc.Version != DocumentVersion {
+		report.Warnf("/Version", "document version %q is not %q, policy variables are not interpreted", doc.Version, DocumentVersion)
+	}
+
+	for i, statement := range doc.Statement {
+		p.Statements = append(p.Statements, importStatement(statement, validator.JSONPointer("Statement", i), opts, report)...)
+	}
+
+	if len(report.Unsupported()) > 0 && !opts.SkipUnsupported {
+		return policy.Policy{}, report, &convert.ConversionError{Report: report}
+	}
+	return p, report, nil
+}
+
+func importStatement(s Statement, path string, opts ImportOptions, report *convert.Report) []policy.Statement {
+	before := len(report.Unsupported())
+
+	if s.Effect != string(policy.Allow) && s.Effect != string(policy.Deny) {
+		report.Unsupportedf(path+"/Effect", "invalid effect %q", s.Effect)
+	}
+	if len(s.Principal) > 0 {
+		report.Unsupportedf(path+"/Principal", "Principal is not supported")
+	}
+	if len(s.NotPrincipal) > 0 {
+		report.Unsupportedf(path+"/NotPrincipal", "NotPrincipal is not supported")
+	}
+	if len(s.NotAction) > 0 {
+		report.Unsupportedf(path+"/NotAction", "NotAction is not supported")
+	}
+	if len(s.NotResource) > 0 {
+		report.Unsupportedf(path+"/NotResource", "NotResource is not supported")
+	}
+	if len(s.Action) == 0 && len(s.NotAction) == 0 {
+		report.Unsupportedf(path, "statement has no Action")
+	}
+	if len(s.Resource) == 0 && len(s.NotResource) == 0 {
+		report.Unsupportedf(path, "statement has no Resource")
+	}
+
+	actions := make([]policy.Action, len(s.Action))
+	for i, action := range s.Action {
+		if hasPolicyVariable(action) {
+			report.Unsupportedf(path+validator.JSONPointer("Action", i), "policy variables are not supported: %q", action)
+		}
+		actions[i] = policy.Action(action)
+	}
+	resources := make([]policy.Resource, len(s.Resource))
+	for i, resource := range s.Resource {
+		resourcePath := path + validator.JSONPointer("Resource", i)
+		if hasPolicyVariable(resource) {
+			report.Unsupportedf(resourcePath, "policy variables are not supported: %q", resource)
+		}
+		translated, exact := importResource(resource)
+		if string(translated) != resource {
+			report.Infof(resourcePath, "%q imported as %q to keep matching across %q", resource, translated, resourceSeparator)
+		}
+		if !exact {
+			if s.Effect == string(policy.Deny) && !opts.AllowNarrowedDeny {
+				report.Unsupportedf(resourcePath, "%q would deny less: wildcards before the end no longer match %q", resource, resourceSeparator)
+			} else {
+				report.Warnf(resourcePath, "%q matches less: wildcards before the end no longer match %q", resource, resourceSeparator)
+			}
+		}
+		resources[i] = translated
+	}
+
+	conditions, alternatives := importConditions(s.Condition, path+"/Condition", report)
+
+	variants := 1
+	for _, group := range alternatives {
+		variants *= len(group)
+		if variants > maxExpandedStatements {
+			report.Unsupportedf(path+"/Condition", "multi-valued conditions would expand to more than %d statements", maxExpandedStatements)
+			break
+		}
+	}
+
+	if len(report.Unsupported()) > before {
+		return nil
+	}
+
+	if variants > 1 {
+		report.Infof(path+"/Condition", "statement split into %d statements, one per combination of condition values", variants)
+	}
+
+	statements := make([]policy.Statement, 0, variants)
+	for n := 0; n < variants; n++ {
+		statement := policy.Statement{
+			ID:         s.Sid,
+			Effect:     policy.Effect(s.Effect),
+			Actions:    actions,
+			Resources:  resources,
+			Conditions: append([]policy.Condition(nil), conditions...),
+		}
+		if variants > 1 && s.Sid != "" {
+			statement.ID = fmt.Sprintf("%s-%d", s.Sid, n+1)
+		}
+		rest := n
+		for _, group := range alternatives {
+			statement.Conditions = append(statement.Conditions, group[rest%len(group)])
+			rest /= len(group)
+		}
+		statements = append(statements, statement)
+	}
+	return statements
+}
+
+func importConditions(block map[string]map[string]ValueList, path string, report *convert.Report) ([]policy.Condition, [][]policy.Condition) {
+	var conditions []policy.Condition
+	var alternatives [][]policy.Condition
+
+	names := make([]string, 0, len(block))
+	for name := range block {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		operatorPath := path + validator.JSONPointer(name)
+		operator, ok := operators[name]
+		if !ok {
+			report.Unsupportedf(operatorPath, "condition operator %q is not supported", name)
+			continue
+		}
+		if negatedOperators[operator] {
+			report.Unsupportedf(operatorPath, "%s is true for a missing key in IAM but false here", name)
+			continue
+		}
+
+		keys := make([]string, 0, len(block[name]))
+		for key := range block[name] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			keyPath := operatorPath + validator.JSONPointer(key)
+			var group []policy.Condition
+			for _, value := range block[name][key] {
+				converted, ok := importValue(operator, value, keyPath, report)
+				if !ok {
+					continue
+				}
+				group = append(group, policy.Condition{Operator: operator, Key: policy.ConditionKey(key), Value: converted})
+			}
+			switch {
+			case len(group) == 0:
+				report.Unsupportedf(keyPath, "condition key has no values")
+			case len(group) == 1:
+				conditions = append(conditions, group...)
+			default:
+				alternatives = append(alternatives, group)
+			}
+		}
+	}
+	return conditions, alternatives
+}
+
+func importValue(operator policy.ConditionOperator, value interface{}, path string, report *convert.Report) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		if hasPolicyVariable(v) {
+			report.Unsupportedf(path, "policy variables are not supported: %q", v)
+			return nil, false
+		}
+		if operator == policy.Bool {
+			switch strings.ToLower(v) {
+			case "true":
+				return true, true
+			case "false":
+				return false, true
+			}
+			report.Unsupportedf(path, "invalid Bool value %q", v)
+			return nil, false
+		}
+		if (operator == policy.StringLike || operator == policy.StringNotLike) && strings.Contains(v, "?") {
+			report.Warnf(path, "%q uses \"?\", which only matches a single character with a glob pattern matcher", v)
+		}
+		return v, true
+	case nil, []interface{}, map[string]interface{}:
+		report.Unsupportedf(path, "condition values must be scalars")
+		return nil, false
+	}
+	return value, true
+}
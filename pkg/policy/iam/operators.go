@@ -0,0 +1,50 @@
+package iam
+
+import (
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+var operators = map[string]policy.ConditionOperator{
+	"StringEquals":              policy.StringEquals,
+	"StringNotEquals":           policy.StringNotEquals,
+	"StringEqualsIgnoreCase":    policy.StringEqualsIgnoreCase,
+	"StringNotEqualsIgnoreCase": policy.StringNotEqualsIgnoreCase,
+	"StringLike":                policy.StringLike,
+	"StringNotLike":             policy.StringNotLike,
+	"NumericEquals":             policy.NumericEquals,
+	"NumericNotEquals":          policy.NumericNotEquals,
+	"NumericLessThan":           policy.NumericLessThan,
+	"NumericLessThanEquals":     policy.NumericLessThanEquals,
+	"NumericGreaterThan":        policy.NumericGreaterThan,
+	"NumericGreaterThanEquals":  policy.NumericGreaterThanEquals,
+	"DateEquals":                policy.DateEquals,
+	"DateNotEquals":             policy.DateNotEquals,
+	"DateLessThan":              policy.DateLessThan,
+	"DateLessThanEquals":        policy.DateLessThanEquals,
+	"DateGreaterThan":           policy.DateGreaterThan,
+	"DateGreaterThanEquals":     policy.DateGreaterThanEquals,
+	"Bool":                      policy.Bool,
+}
+
+var negatedOperators = map[policy.ConditionOperator]bool{
+	policy.StringNotEquals:           true,
+	policy.StringNotEqualsIgnoreCase: true,
+	policy.StringNotLike:             true,
+	policy.NumericNotEquals:          true,
+	policy.DateNotEquals:             true,
+}
+
+func iamOperatorName(operator policy.ConditionOperator) (string, bool) {
+	for name, op := range operators {
+		if op == operator {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func hasPolicyVariable(s string) bool {
+	return strings.Contains(s, "${")
+}
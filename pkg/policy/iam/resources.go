@@ -0,0 +1,40 @@
+package iam
+
+import (
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+const anySegments = "**"
+
+var resourceSeparator = policy.DefaultResourceNameSyntax.SegmentSeparator
+
+func importResource(resource string) (policy.Resource, bool) {
+	body := strings.TrimRight(resource, "*")
+	if body == "" {
+		return "*", true
+	}
+	translated := resource
+	if body != resource {
+		translated = body + "*" + resourceSeparator + anySegments
+	}
+	return policy.Resource(translated), !strings.ContainsAny(body, "*?")
+}
+
+func exportResource(resource policy.Resource) (pattern string, widened bool, ok bool) {
+	pattern = string(resource)
+	if pattern == "*" {
+		return pattern, false, true
+	}
+	body := strings.TrimSuffix(pattern, "*"+resourceSeparator+anySegments)
+	if body != pattern {
+		pattern = body + "*"
+	}
+	for _, segment := range strings.Split(pattern, resourceSeparator) {
+		if segment == anySegments {
+			return "", false, false
+		}
+	}
+	return pattern, strings.ContainsAny(body, "*?"), true
+}
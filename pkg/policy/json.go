@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"time"
@@ -58,6 +59,22 @@ func (p *Policy) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// UnmarshalJSON keeps numeric condition values as json.Number.
+func (c *Condition) UnmarshalJSON(data []byte) error {
+	type Alias Condition
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode((*Alias)(c))
+}
+
+// UnmarshalJSON keeps numeric parameter values as json.Number.
+func (r *TemplateReference) UnmarshalJSON(data []byte) error {
+	type Alias TemplateReference
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode((*Alias)(r))
+}
+
 func FromJSON(jsonStr string) (Policy, error) {
 	var p Policy
 	err := json.Unmarshal([]byte(jsonStr), &p)
@@ -87,7 +104,7 @@ func (p Policy) ToJSONIndent() (string, error) {
 }
 
 type PolicyList struct {
-	Policies []Policy `json:"policies"`
+	Policies []Policy `json:"policies" yaml:"policies"`
 }
 
 func FromJSONList(jsonStr string) ([]Policy, error) {
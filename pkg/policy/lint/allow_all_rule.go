@@ -0,0 +1,66 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+type AllowAllRule struct{}
+
+func NewAllowAllRule() *AllowAllRule {
+	return &AllowAllRule{}
+}
+
+func (r *AllowAllRule) ID() string {
+	return AllowAllRuleID
+}
+
+func (r *AllowAllRule) DefaultSeverity() validator.Severity {
+	return validator.SeverityWarning
+}
+
+func (r *AllowAllRule) Check(p policy.Policy) []Finding {
+	var findings []Finding
+	for i, statement := range p.Statements {
+		if statement.Effect != policy.Allow || !containsAction(statement.Actions, "*") || !containsResource(statement.Resources, "*") {
+			continue
+		}
+		message := fmt.Sprintf("%s allows every action on every resource", statementLabel(statement, i))
+		var restrictions []string
+		if len(statement.Principals) > 0 {
+			restrictions = append(restrictions, "principals")
+		}
+		if len(statement.Conditions) > 0 {
+			restrictions = append(restrictions, "conditions")
+		}
+		if len(restrictions) > 0 {
+			message += ", restricted only by its " + strings.Join(restrictions, " and ")
+		}
+		findings = append(findings, Finding{
+			Path:    statementPath(i),
+			Message: message,
+		})
+	}
+	return findings
+}
+
+func containsAction(actions []policy.Action, action policy.Action) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+func containsResource(resources []policy.Resource, resource policy.Resource) bool {
+	for _, r := range resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+type DuplicateStatementIDRule struct{}
+
+func NewDuplicateStatementIDRule() *DuplicateStatementIDRule {
+	return &DuplicateStatementIDRule{}
+}
+
+func (r *DuplicateStatementIDRule) ID() string {
+	return DuplicateStatementIDRuleID
+}
+
+func (r *DuplicateStatementIDRule) DefaultSeverity() validator.Severity {
+	return validator.SeverityError
+}
+
+func (r *DuplicateStatementIDRule) Check(p policy.Policy) []Finding {
+	var findings []Finding
+	firstIndex := make(map[string]int)
+	for i, statement := range p.Statements {
+		if statement.ID == "" {
+			continue
+		}
+		if first, exists := firstIndex[statement.ID]; exists {
+			findings = append(findings, Finding{
+				Path:    statementPath(i) + "/id",
+				Message: fmt.Sprintf("Statement ID %q is already used by %s", statement.ID, statementPath(first)),
+			})
+			continue
+		}
+		firstIndex[statement.ID] = i
+	}
+	return findings
+}
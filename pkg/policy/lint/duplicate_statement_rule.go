@@ -0,0 +1,39 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+type DuplicateStatementRule struct{}
+
+func NewDuplicateStatementRule() *DuplicateStatementRule {
+	return &DuplicateStatementRule{}
+}
+
+func (r *DuplicateStatementRule) ID() string {
+	return DuplicateStatementRuleID
+}
+
+func (r *DuplicateStatementRule) DefaultSeverity() validator.Severity {
+	return validator.SeverityWarning
+}
+
+func (r *DuplicateStatementRule) Check(p policy.Policy) []Finding {
+	var findings []Finding
+	for i, statement := range p.Statements {
+		for j := 0; j < i; j++ {
+			if sameStatement(p.Statements[j], statement) {
+				findings = append(findings, Finding{
+					Path: statementPath(i),
+					Message: fmt.Sprintf("%s duplicates %s",
+						statementLabel(statement, i), statementLabel(p.Statements[j], j)),
+				})
+				break
+			}
+		}
+	}
+	return findings
+}
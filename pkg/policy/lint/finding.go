@@ -0,0 +1,28 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+type Finding struct {
+	RuleID   string
+	Severity validator.Severity
+	// Path is an RFC 6901 JSON pointer into the policy.
+	Path    string
+	Message string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s [%s] %s: %s", f.Severity, f.RuleID, f.Path, f.Message)
+}
+
+type RuleConfig struct {
+	Disabled bool               `json:"disabled,omitempty"`
+	Severity validator.Severity `json:"severity,omitempty"`
+}
+
+type Config struct {
+	Rules map[string]RuleConfig `json:"rules,omitempty"`
+}
@@ -0,0 +1,69 @@
+package lint
+
+import (
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/coverage"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+)
+
+const (
+	DuplicateStatementIDRuleID   = "duplicate-statement-id"
+	ShadowedStatementRuleID      = "shadowed-statement"
+	DuplicateStatementRuleID     = "duplicate-statement"
+	SubsumedStatementRuleID      = "subsumed-statement"
+	AllowAllRuleID               = "allow-all"
+	UnsatisfiableConditionRuleID = "unsatisfiable-conditions"
+)
+
+type Linter struct {
+	config Config
+	rules  []IRule
+}
+
+func NewLinter(config Config, rules ...IRule) *Linter {
+	return &Linter{
+		config: config,
+		rules:  rules,
+	}
+}
+
+// NewDefaultLinter compares patterns with the default condition factory settings.
+func NewDefaultLinter(config Config) *Linter {
+	return NewDefaultLinterWithCoverage(config, coverage.NewDefaultCoverage())
+}
+
+// NewDefaultLinterWithConditionProvider compares patterns with the matchers of provider.
+func NewDefaultLinterWithConditionProvider(config Config, provider evaluator.IConditionProvider) *Linter {
+	return NewDefaultLinterWithCoverage(config, coverage.NewCoverageFromProvider(provider))
+}
+
+func NewDefaultLinterWithCoverage(config Config, statementCoverage *coverage.Coverage) *Linter {
+	return NewLinter(config,
+		NewDuplicateStatementIDRule(),
+		NewShadowedStatementRule(statementCoverage),
+		NewDuplicateStatementRule(),
+		NewSubsumedStatementRule(statementCoverage),
+		NewAllowAllRule(),
+		NewUnsatisfiableConditionRule(),
+	)
+}
+
+func (l *Linter) Lint(p policy.Policy) []Finding {
+	var findings []Finding
+	for _, rule := range l.rules {
+		ruleConfig := l.config.Rules[rule.ID()]
+		if ruleConfig.Disabled {
+			continue
+		}
+		severity := rule.DefaultSeverity()
+		if ruleConfig.Severity != "" {
+			severity = ruleConfig.Severity
+		}
+		for _, finding := range rule.Check(p) {
+			finding.RuleID = rule.ID()
+			finding.Severity = severity
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
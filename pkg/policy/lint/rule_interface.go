@@ -0,0 +1,12 @@
+package lint
+
+import (
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+type IRule interface {
+	ID() string
+	DefaultSeverity() validator.Severity
+	Check(p policy.Policy) []Finding
+}
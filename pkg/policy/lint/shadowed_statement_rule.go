@@ -0,0 +1,54 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/coverage"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+// ShadowedStatementRule flags statements covered by a Deny statement.
+type ShadowedStatementRule struct {
+	coverage *coverage.Coverage
+}
+
+func NewShadowedStatementRule(statementCoverage *coverage.Coverage) *ShadowedStatementRule {
+	return &ShadowedStatementRule{
+		coverage: statementCoverage,
+	}
+}
+
+func (r *ShadowedStatementRule) ID() string {
+	return ShadowedStatementRuleID
+}
+
+func (r *ShadowedStatementRule) DefaultSeverity() validator.Severity {
+	return validator.SeverityWarning
+}
+
+func (r *ShadowedStatementRule) Check(p policy.Policy) []Finding {
+	var findings []Finding
+	for i, statement := range p.Statements {
+		for j, deny := range p.Statements {
+			if i == j || deny.Effect != policy.Deny {
+				continue
+			}
+			if statement.Effect == policy.Deny && j > i {
+				continue
+			}
+			if statement.Effect == policy.Deny && sameStatement(statement, deny) {
+				continue
+			}
+			if r.coverage.StatementCovers(deny, statement) {
+				findings = append(findings, Finding{
+					Path: statementPath(i),
+					Message: fmt.Sprintf("%s is shadowed by Deny %s and never takes effect",
+						statementLabel(statement, i), statementLabel(deny, j)),
+				})
+				break
+			}
+		}
+	}
+	return findings
+}
@@ -0,0 +1,70 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/coverage"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+func sameStatement(a, b policy.Statement) bool {
+	return a.Effect == b.Effect &&
+		sameSet(principalStrings(a.Principals), principalStrings(b.Principals)) &&
+		sameSet(actionStrings(a.Actions), actionStrings(b.Actions)) &&
+		sameSet(resourceStrings(a.Resources), resourceStrings(b.Resources)) &&
+		coverage.ConditionsSubset(a.Conditions, b.Conditions) &&
+		coverage.ConditionsSubset(b.Conditions, a.Conditions)
+}
+
+func sameSet(a, b []string) bool {
+	set := make(map[string]bool, len(a))
+	for _, item := range a {
+		set[item] = true
+	}
+	for _, item := range b {
+		if !set[item] {
+			return false
+		}
+	}
+	other := make(map[string]bool, len(b))
+	for _, item := range b {
+		other[item] = true
+	}
+	return len(set) == len(other)
+}
+
+func principalStrings(principals []policy.Principal) []string {
+	result := make([]string, len(principals))
+	for i, principal := range principals {
+		result[i] = string(principal)
+	}
+	return result
+}
+
+func actionStrings(actions []policy.Action) []string {
+	result := make([]string, len(actions))
+	for i, action := range actions {
+		result[i] = string(action)
+	}
+	return result
+}
+
+func resourceStrings(resources []policy.Resource) []string {
+	result := make([]string, len(resources))
+	for i, resource := range resources {
+		result[i] = string(resource)
+	}
+	return result
+}
+
+func statementPath(index int) string {
+	return validator.JSONPointer("statements", index)
+}
+
+func statementLabel(statement policy.Statement, index int) string {
+	if statement.ID != "" {
+		return fmt.Sprintf("statement %s", statement.ID)
+	}
+	return fmt.Sprintf("statement #%d", index)
+}
@@ -0,0 +1,51 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/coverage"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+// SubsumedStatementRule flags statements covered by a broader one with the same effect.
+type SubsumedStatementRule struct {
+	coverage *coverage.Coverage
+}
+
+func NewSubsumedStatementRule(statementCoverage *coverage.Coverage) *SubsumedStatementRule {
+	return &SubsumedStatementRule{
+		coverage: statementCoverage,
+	}
+}
+
+func (r *SubsumedStatementRule) ID() string {
+	return SubsumedStatementRuleID
+}
+
+func (r *SubsumedStatementRule) DefaultSeverity() validator.Severity {
+	return validator.SeverityInfo
+}
+
+func (r *SubsumedStatementRule) Check(p policy.Policy) []Finding {
+	var findings []Finding
+	for i, statement := range p.Statements {
+		for j, other := range p.Statements {
+			if i == j || other.Effect != statement.Effect || sameStatement(statement, other) {
+				continue
+			}
+			if statement.Effect == policy.Deny && j < i {
+				continue
+			}
+			if r.coverage.StatementCovers(other, statement) {
+				findings = append(findings, Finding{
+					Path: statementPath(i),
+					Message: fmt.Sprintf("%s is subsumed by the broader %s",
+						statementLabel(statement, i), statementLabel(other, j)),
+				})
+				break
+			}
+		}
+	}
+	return findings
+}
@@ -0,0 +1,201 @@
+package lint
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+// UnsatisfiableConditionRule flags statements whose conditions can never hold together.
+type UnsatisfiableConditionRule struct{}
+
+func NewUnsatisfiableConditionRule() *UnsatisfiableConditionRule {
+	return &UnsatisfiableConditionRule{}
+}
+
+func (r *UnsatisfiableConditionRule) ID() string {
+	return UnsatisfiableConditionRuleID
+}
+
+func (r *UnsatisfiableConditionRule) DefaultSeverity() validator.Severity {
+	return validator.SeverityError
+}
+
+func (r *UnsatisfiableConditionRule) Check(p policy.Policy) []Finding {
+	var findings []Finding
+	for i, statement := range p.Statements {
+		if reason := conflictingConditions(statement.Conditions); reason != "" {
+			findings = append(findings, Finding{
+				Path:    statementPath(i) + "/conditions",
+				Message: fmt.Sprintf("%s can never match: %s", statementLabel(statement, i), reason),
+			})
+		}
+	}
+	return findings
+}
+
+type bound struct {
+	value     interface{}
+	inclusive bool
+	cond      policy.Condition
+}
+
+type keyConstraints struct {
+	lower     []bound
+	upper     []bound
+	equals    []policy.Condition
+	notEquals []policy.Condition
+}
+
+type comparator func(a, b interface{}) (int, bool)
+
+func conflictingConditions(conditions []policy.Condition) string {
+	numeric := make(map[policy.ConditionKey]*keyConstraints)
+	dates := make(map[policy.ConditionKey]*keyConstraints)
+	strs := make(map[policy.ConditionKey]*keyConstraints)
+	bools := make(map[policy.ConditionKey]*keyConstraints)
+
+	for _, cond := range conditions {
+		if cond.Key == "" {
+			continue
+		}
+		switch cond.Operator {
+		case policy.NumericGreaterThan, policy.NumericGreaterThanEquals,
+			policy.NumericLessThan, policy.NumericLessThanEquals,
+			policy.NumericEquals, policy.NumericNotEquals:
+			addConstraint(numeric, cond)
+		case policy.DateGreaterThan, policy.DateGreaterThanEquals,
+			policy.DateLessThan, policy.DateLessThanEquals,
+			policy.DateEquals, policy.DateNotEquals:
+			if s, ok := cond.Value.(string); ok && condition.IsRelativeTime(s) {
+				continue
+			}
+			addConstraint(dates, cond)
+		case policy.StringEquals, policy.StringNotEquals:
+			if _, ok := cond.Value.(string); ok {
+				addConstraint(strs, cond)
+			}
+		case policy.Bool:
+			if _, ok := cond.Value.(bool); ok {
+				addConstraint(bools, cond)
+			}
+		}
+	}
+
+	for _, group := range []struct {
+		constraints map[policy.ConditionKey]*keyConstraints
+		compare     comparator
+	}{
+		{numeric, compareNumbers},
+		{dates, compareDates},
+		{strs, compareEqual},
+		{bools, compareEqual},
+	} {
+		for _, constraints := range group.constraints {
+			if reason := constraints.conflict(group.compare); reason != "" {
+				return reason
+			}
+		}
+	}
+	return ""
+}
+
+func addConstraint(groups map[policy.ConditionKey]*keyConstraints, cond policy.Condition) {
+	constraints, exists := groups[cond.Key]
+	if !exists {
+		constraints = &keyConstraints{}
+		groups[cond.Key] = constraints
+	}
+	switch cond.Operator {
+	case policy.NumericGreaterThan, policy.DateGreaterThan:
+		constraints.lower = append(constraints.lower, bound{value: cond.Value, cond: cond})
+	case policy.NumericGreaterThanEquals, policy.DateGreaterThanEquals:
+		constraints.lower = append(constraints.lower, bound{value: cond.Value, inclusive: true, cond: cond})
+	case policy.NumericLessThan, policy.DateLessThan:
+		constraints.upper = append(constraints.upper, bound{value: cond.Value, cond: cond})
+	case policy.NumericLessThanEquals, policy.DateLessThanEquals:
+		constraints.upper = append(constraints.upper, bound{value: cond.Value, inclusive: true, cond: cond})
+	case policy.NumericNotEquals, policy.DateNotEquals, policy.StringNotEquals:
+		constraints.notEquals = append(constraints.notEquals, cond)
+	default:
+		constraints.equals = append(constraints.equals, cond)
+	}
+}
+
+func (k *keyConstraints) conflict(compare comparator) string {
+	for i, a := range k.equals {
+		for _, b := range k.equals[i+1:] {
+			if c, ok := compare(a.Value, b.Value); ok && c != 0 {
+				return describeConflict(a, b)
+			}
+		}
+		for _, b := range k.notEquals {
+			if c, ok := compare(a.Value, b.Value); ok && c == 0 {
+				return describeConflict(a, b)
+			}
+		}
+		for _, lower := range k.lower {
+			if c, ok := compare(a.Value, lower.value); ok && (c < 0 || (c == 0 && !lower.inclusive)) {
+				return describeConflict(a, lower.cond)
+			}
+		}
+		for _, upper := range k.upper {
+			if c, ok := compare(a.Value, upper.value); ok && (c > 0 || (c == 0 && !upper.inclusive)) {
+				return describeConflict(a, upper.cond)
+			}
+		}
+	}
+	for _, lower := range k.lower {
+		for _, upper := range k.upper {
+			c, ok := compare(lower.value, upper.value)
+			if ok && (c > 0 || (c == 0 && !(lower.inclusive && upper.inclusive))) {
+				return describeConflict(lower.cond, upper.cond)
+			}
+		}
+	}
+	return ""
+}
+
+func describeConflict(a, b policy.Condition) string {
+	return fmt.Sprintf("%s %s %v conflicts with %s %s %v", a.Key, a.Operator, a.Value, b.Key, b.Operator, b.Value)
+}
+
+func compareNumbers(a, b interface{}) (int, bool) {
+	x, err := condition.ParseNumber(a)
+	if err != nil {
+		return 0, false
+	}
+	y, err := condition.ParseNumber(b)
+	if err != nil {
+		return 0, false
+	}
+	return x.Compare(y)
+}
+
+func compareDates(a, b interface{}) (int, bool) {
+	x, err := condition.ParseTime(a, time.Time{})
+	if err != nil {
+		return 0, false
+	}
+	y, err := condition.ParseTime(b, time.Time{})
+	if err != nil {
+		return 0, false
+	}
+	switch {
+	case x.Before(y):
+		return -1, true
+	case x.After(y):
+		return 1, true
+	}
+	return 0, true
+}
+
+func compareEqual(a, b interface{}) (int, bool) {
+	if a == b {
+		return 0, true
+	}
+	return 1, true
+}
@@ -0,0 +1,14 @@
+package migration
+
+// Document is the raw JSON object form of a policy.
+type Document map[string]interface{}
+
+// MigrateFunc upgrades doc in place to the next version, recording changes in the report.
+type MigrateFunc func(doc Document, report *Report) error
+
+type Migration struct {
+	From        string
+	To          string
+	Description string
+	Migrate     MigrateFunc
+}
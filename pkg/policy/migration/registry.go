@@ -0,0 +1,207 @@
+package migration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+// Registry upgrades policy documents step by step to the current version.
+type Registry struct {
+	current string
+
+	mu         sync.RWMutex
+	migrations map[string]Migration
+}
+
+func NewRegistry(currentVersion string) *Registry {
+	return &Registry{
+		current:    currentVersion,
+		migrations: make(map[string]Migration),
+	}
+}
+
+func NewDefaultRegistry() *Registry {
+	return NewRegistry(policy.PolicyVersion)
+}
+
+func (r *Registry) CurrentVersion() string {
+	return r.current
+}
+
+func (r *Registry) Register(migration Migration) error {
+	if migration.From == "" || migration.To == "" {
+		return fmt.Errorf("migration source and target versions are required")
+	}
+	if migration.From == migration.To {
+		return fmt.Errorf("migration from %s must change the version", migration.From)
+	}
+	if migration.From == r.current {
+		return fmt.Errorf("cannot migrate away from the current version %s", r.current)
+	}
+	if migration.Migrate == nil {
+		return fmt.Errorf("migration from %s to %s must define Migrate", migration.From, migration.To)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.migrations[migration.From]; exists {
+		return fmt.Errorf("a migration from version %s is already registered", migration.From)
+	}
+	r.migrations[migration.From] = migration
+	return nil
+}
+
+// SupportedVersions returns every version with a migration path to the current one.
+func (r *Registry) SupportedVersions() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := []string{r.current}
+	for from := range r.migrations {
+		if r.reachesCurrent(from) {
+			versions = append(versions, from)
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+func (r *Registry) IsSupported(version string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.reachesCurrent(version)
+}
+
+func (r *Registry) reachesCurrent(version string) bool {
+	for steps := 0; steps <= len(r.migrations); steps++ {
+		if version == r.current {
+			return true
+		}
+		migration, ok := r.migrations[version]
+		if !ok {
+			return false
+		}
+		version = migration.To
+	}
+	return false
+}
+
+// Migrate upgrades a copy of doc to the current version.
+func (r *Registry) Migrate(doc Document) (Document, *Report, error) {
+	version, _ := doc["version"].(string)
+	if version == "" {
+		return nil, nil, fmt.Errorf("policy document has no version")
+	}
+	if !r.IsSupported(version) {
+		return nil, nil, fmt.Errorf("unsupported policy version %q", version)
+	}
+
+	migrated, err := copyDocument(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	report := &Report{FromVersion: version, ToVersion: r.current}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for version != r.current {
+		migration := r.migrations[version]
+		report.current = migration.From + "->" + migration.To
+		if err := migration.Migrate(migrated, report); err != nil {
+			return nil, nil, fmt.Errorf("migrating policy from %s to %s: %v", migration.From, migration.To, err)
+		}
+		migrated["version"] = migration.To
+		report.Record("/version", fmt.Sprintf("version changed from %s to %s", migration.From, migration.To))
+		report.Applied = append(report.Applied, report.current)
+		version = migration.To
+	}
+	return migrated, report, nil
+}
+
+// MigrateJSON decodes and upgrades a policy document.
+func (r *Registry) MigrateJSON(data []byte) (policy.Policy, *Report, error) {
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return policy.Policy{}, nil, err
+	}
+	migrated, report, err := r.Migrate(doc)
+	if err != nil {
+		return policy.Policy{}, nil, err
+	}
+	encoded, err := json.Marshal(migrated)
+	if err != nil {
+		return policy.Policy{}, nil, err
+	}
+	var p policy.Policy
+	if err := json.Unmarshal(encoded, &p); err != nil {
+		return policy.Policy{}, nil, err
+	}
+	return p, report, nil
+}
+
+// MigrateJSONList loads and upgrades a {"policies": [...]} list.
+func (r *Registry) MigrateJSONList(data []byte) ([]policy.Policy, []*Report, error) {
+	return r.migrateStream(data, policy.NewJSONListDecoder)
+}
+
+// MigrateJSONArray loads and upgrades a JSON array of policies.
+func (r *Registry) MigrateJSONArray(data []byte) ([]policy.Policy, []*Report, error) {
+	return r.migrateStream(data, policy.NewJSONArrayDecoder)
+}
+
+// StreamMigration returns a decode function for policy.WithStreamMigration.
+func (r *Registry) StreamMigration(report func(*Report)) func([]byte) (policy.Policy, error) {
+	return func(data []byte) (policy.Policy, error) {
+		p, migrationReport, err := r.MigrateJSON(data)
+		if err != nil {
+			return policy.Policy{}, err
+		}
+		if report != nil {
+			report(migrationReport)
+		}
+		return p, nil
+	}
+}
+
+func (r *Registry) migrateStream(data []byte, newDecoder func(io.Reader, ...policy.StreamOption) *policy.PolicyDecoder) ([]policy.Policy, []*Report, error) {
+	var reports []*Report
+	decoder := newDecoder(bytes.NewReader(data), policy.WithStreamMigration(r.StreamMigration(func(report *Report) {
+		reports = append(reports, report)
+	})))
+	var policies []policy.Policy
+	err := decoder.Each(func(index int, p policy.Policy) error {
+		policies = append(policies, p)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return policies, reports, nil
+}
+
+func decodeDocument(data []byte) (Document, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc Document
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func copyDocument(doc Document) (Document, error) {
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDocument(encoded)
+}
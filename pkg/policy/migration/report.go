@@ -0,0 +1,42 @@
+package migration
+
+import "fmt"
+
+type Change struct {
+	Migration string `json:"migration"`
+	// Path is an RFC 6901 JSON pointer to the changed location.
+	Path        string `json:"path"`
+	Description string `json:"description"`
+}
+
+type Report struct {
+	FromVersion string   `json:"from_version"`
+	ToVersion   string   `json:"to_version"`
+	Applied     []string `json:"applied,omitempty"`
+	Changes     []Change `json:"changes,omitempty"`
+
+	current string
+}
+
+func (r *Report) Record(path, description string) {
+	r.Changes = append(r.Changes, Change{
+		Migration:   r.current,
+		Path:        path,
+		Description: description,
+	})
+}
+
+func (r *Report) Migrated() bool {
+	return len(r.Applied) > 0
+}
+
+func (r *Report) String() string {
+	if !r.Migrated() {
+		return fmt.Sprintf("policy is at version %s, no migration needed", r.ToVersion)
+	}
+	s := fmt.Sprintf("migrated policy from version %s to %s", r.FromVersion, r.ToVersion)
+	for _, change := range r.Changes {
+		s += fmt.Sprintf("\n  [%s] %s: %s", change.Migration, change.Path, change.Description)
+	}
+	return s
+}
@@ -0,0 +1,91 @@
+package policy
+
+import (
+	"sort"
+)
+
+type OperatorFamily string
+
+const (
+	StringFamily   OperatorFamily = "String"
+	NumericFamily  OperatorFamily = "Numeric"
+	DateFamily     OperatorFamily = "Date"
+	BoolFamily     OperatorFamily = "Bool"
+	VersionFamily  OperatorFamily = "Version"
+	DurationFamily OperatorFamily = "Duration"
+	ScheduleFamily OperatorFamily = "Schedule"
+	ListFamily     OperatorFamily = "List"
+)
+
+var operatorFamilies = map[ConditionOperator]OperatorFamily{
+	StringEquals:              StringFamily,
+	StringNotEquals:           StringFamily,
+	StringEqualsIgnoreCase:    StringFamily,
+	StringNotEqualsIgnoreCase: StringFamily,
+	StringLike:                StringFamily,
+	StringNotLike:             StringFamily,
+	StringMatchesRegex:        StringFamily,
+	StringNotMatchesRegex:     StringFamily,
+
+	NumericEquals:            NumericFamily,
+	NumericNotEquals:         NumericFamily,
+	NumericLessThan:          NumericFamily,
+	NumericLessThanEquals:    NumericFamily,
+	NumericGreaterThan:       NumericFamily,
+	NumericGreaterThanEquals: NumericFamily,
+
+	DateEquals:            DateFamily,
+	DateNotEquals:         DateFamily,
+	DateLessThan:          DateFamily,
+	DateLessThanEquals:    DateFamily,
+	DateGreaterThan:       DateFamily,
+	DateGreaterThanEquals: DateFamily,
+
+	Bool: BoolFamily,
+
+	VersionEquals:            VersionFamily,
+	VersionNotEquals:         VersionFamily,
+	VersionLessThan:          VersionFamily,
+	VersionLessThanEquals:    VersionFamily,
+	VersionGreaterThan:       VersionFamily,
+	VersionGreaterThanEquals: VersionFamily,
+
+	DurationEquals:            DurationFamily,
+	DurationNotEquals:         DurationFamily,
+	DurationLessThan:          DurationFamily,
+	DurationLessThanEquals:    DurationFamily,
+	DurationGreaterThan:       DurationFamily,
+	DurationGreaterThanEquals: DurationFamily,
+
+	ScheduleTimeOfDay: ScheduleFamily,
+	ScheduleDayOfWeek: ScheduleFamily,
+	ScheduleCron:      ScheduleFamily,
+
+	ListContains:    ListFamily,
+	ListNotContains: ListFamily,
+}
+
+// Family returns the family of a built-in operator.
+func (o ConditionOperator) Family() (OperatorFamily, bool) {
+	family, ok := operatorFamilies[o]
+	return family, ok
+}
+
+func (o ConditionOperator) IsBuiltin() bool {
+	_, ok := operatorFamilies[o]
+	return ok
+}
+
+// IsSchedule reports whether the operator may omit its condition key.
+func (o ConditionOperator) IsSchedule() bool {
+	return operatorFamilies[o] == ScheduleFamily
+}
+
+func BuiltinOperators() []ConditionOperator {
+	operators := make([]ConditionOperator, 0, len(operatorFamilies))
+	for operator := range operatorFamilies {
+		operators = append(operators, operator)
+	}
+	sort.Slice(operators, func(i, j int) bool { return operators[i] < operators[j] })
+	return operators
+}
@@ -0,0 +1,243 @@
+package rego
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+)
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// Input builds the OPA input document for a request.
+func Input(req evaluator.Request) (map[string]interface{}, error) {
+	data, err := json.Marshal(map[string]interface{}{
+		"principal": req.Principal,
+		"action":    req.Action,
+		"resource":  req.Resource,
+		"context":   req.Context,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var input map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// Eval computes the module's allow rule for an input document without OPA.
+func (m *Module) Eval(input map[string]interface{}) bool {
+	allowed, denied := false, false
+	for _, rule := range m.Rules {
+		if !m.evalBody(rule.Body, input) {
+			continue
+		}
+		if rule.Effect == policy.Deny {
+			denied = true
+		} else {
+			allowed = true
+		}
+	}
+	return allowed && !denied
+}
+
+func (m *Module) evalBody(body []Expr, input map[string]interface{}) bool {
+	for _, expr := range body {
+		if m.evalExpr(expr, input) == expr.Negated {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Module) evalExpr(expr Expr, input map[string]interface{}) bool {
+	left, ok := m.evalTerm(expr.Left, input)
+	if !ok {
+		return false
+	}
+	if expr.Op == "" {
+		return left != false
+	}
+	right, ok := m.evalTerm(expr.Right, input)
+	if !ok {
+		return false
+	}
+	return compare(expr.Op, left, right)
+}
+
+func (m *Module) evalTerm(t Term, input map[string]interface{}) (interface{}, bool) {
+	if t.Call == "" && t.Ref == "" {
+		return t.Value, true
+	}
+
+	args := make([]interface{}, len(t.Args))
+	for i, arg := range t.Args {
+		value, ok := m.evalTerm(arg, input)
+		if !ok {
+			return nil, false
+		}
+		args[i] = value
+	}
+
+	context, _ := input["context"].(map[string]interface{})
+	switch t.Ref {
+	case "input.principal":
+		return lookup(input, "principal")
+	case "input.action":
+		return lookup(input, "action")
+	case "resource_path":
+		resource, ok := input["resource"].(string)
+		return m.ResourceSeparator + resource, ok
+	case "input.context":
+		return lookup(context, args[0])
+	}
+
+	switch t.Call {
+	case "regex.match":
+		pattern, ok1 := args[0].(string)
+		s, ok2 := args[1].(string)
+		if !ok1 || !ok2 {
+			return nil, false
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, false
+		}
+		return regex.MatchString(s), true
+	case "lower":
+		s, ok := args[0].(string)
+		return strings.ToLower(s), ok
+	case "is_string":
+		_, ok := args[0].(string)
+		return ok, true
+	case "has_context":
+		_, ok := lookup(context, args[0])
+		return ok, true
+	case "string_value":
+		value, _ := lookup(context, args[0])
+		s, ok := value.(string)
+		return s, ok
+	case "number_value":
+		value, _ := lookup(context, args[0])
+		return numberValue(value)
+	case "time_value":
+		value, _ := lookup(context, args[0])
+		return timeValue(value)
+	}
+	return nil, false
+}
+
+func lookup(m map[string]interface{}, key interface{}) (interface{}, bool) {
+	k, ok := key.(string)
+	if !ok || m == nil {
+		return nil, false
+	}
+	value, ok := m[k]
+	return value, ok
+}
+
+func numberValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		return v, true
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if _, err := condition.ParseNumber(trimmed); err != nil {
+			return nil, false
+		}
+		return json.Number(trimmed), true
+	}
+	return nil, false
+}
+
+func timeValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return json.Number(strconv.FormatInt(t.UnixNano(), 10)), true
+			}
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			if n < 1e12 && n > -1e12 {
+				return json.Number(strconv.FormatInt(n*1e9, 10)), true
+			}
+			return json.Number(strconv.FormatInt(n*1e6, 10)), true
+		}
+		if f, err := v.Float64(); err == nil {
+			if f < 1e12 && f > -1e12 {
+				return json.Number(strconv.FormatFloat(f*1e9, 'f', 0, 64)), true
+			}
+			return json.Number(strconv.FormatFloat(f*1e6, 'f', 0, 64)), true
+		}
+	}
+	return nil, false
+}
+
+func compare(op string, left, right interface{}) bool {
+	if _, ok := left.(json.Number); ok {
+		return compareNumbers(op, left, right)
+	}
+	if _, ok := right.(json.Number); ok {
+		return compareNumbers(op, left, right)
+	}
+	switch l := left.(type) {
+	case string:
+		r, ok := right.(string)
+		return ok && compareOrdered(op, strings.Compare(l, r))
+	case bool:
+		r, ok := right.(bool)
+		return ok && op == "==" && l == r
+	}
+	return false
+}
+
+func compareNumbers(op string, left, right interface{}) bool {
+	l, err1 := condition.ParseNumber(left)
+	r, err2 := condition.ParseNumber(right)
+	if err1 != nil || err2 != nil || !isNumber(left) || !isNumber(right) {
+		return false
+	}
+	c, ok := l.Compare(r)
+	return ok && compareOrdered(op, c)
+}
+
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case json.Number, int64:
+		return true
+	}
+	return false
+}
+
+func compareOrdered(op string, c int) bool {
+	switch op {
+	case "==":
+		return c == 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
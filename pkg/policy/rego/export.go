@@ -0,0 +1,296 @@
+package rego
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/convert"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+var jsonNumberPattern = regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)(?:\.[0-9]+)?(?:[eE][+-]?[0-9]+)?$`)
+
+// Options describes the matching the exported module should reproduce.
+type Options struct {
+	// Package is the Rego package name, DefaultPackage when empty.
+	Package string
+	// ResourceSeparator is a single character or empty, "/" when nil.
+	ResourceSeparator  *string
+	IncludeDescendants bool
+	// SkipUnsupported drops Allow statements that cannot be exported.
+	SkipUnsupported bool
+}
+
+// Export compiles policies into the text of a Rego module.
+func Export(policies []policy.Policy, opts Options) (string, error) {
+	module, err := Compile(policies, opts)
+	if err != nil {
+		return "", err
+	}
+	return module.String(), nil
+}
+
+// Compile translates policies into a Module.
+func Compile(policies []policy.Policy, opts Options) (*Module, error) {
+	c := &compiler{separator: "/", includeDescendants: opts.IncludeDescendants}
+	if opts.ResourceSeparator != nil {
+		c.separator = *opts.ResourceSeparator
+	}
+	if len(c.separator) > 1 {
+		return nil, fmt.Errorf("resource separator %q must be a single character", c.separator)
+	}
+
+	module := &Module{Package: opts.Package, ResourceSeparator: c.separator}
+	if module.Package == "" {
+		module.Package = DefaultPackage
+	}
+
+	report := &convert.Report{}
+	for i, p := range policies {
+		for j, statement := range p.Statements {
+			c.issues = nil
+			rule, ok := c.compileStatement(statement, validator.JSONPointer("policies", i, "statements", j))
+			if len(c.issues) > 0 && (statement.Effect == policy.Deny || !opts.SkipUnsupported) {
+				report.Issues = append(report.Issues, c.issues...)
+			}
+			if ok && len(c.issues) == 0 {
+				rule.PolicyID = p.ID
+				module.Rules = append(module.Rules, rule)
+			}
+		}
+	}
+
+	if len(report.Issues) > 0 {
+		return nil, &convert.ConversionError{Report: report}
+	}
+	return module, nil
+}
+
+type compiler struct {
+	separator          string
+	includeDescendants bool
+	issues             []convert.Issue
+}
+
+func (c *compiler) unsupported(path, format string, args ...interface{}) {
+	c.issues = append(c.issues, convert.Issue{Severity: validator.SeverityError, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *compiler) compileStatement(s policy.Statement, path string) (Rule, bool) {
+	rule := Rule{Effect: s.Effect, StatementID: s.ID}
+	if s.Effect != policy.Allow && s.Effect != policy.Deny {
+		c.unsupported(path+"/effect", "invalid effect %q", s.Effect)
+		return rule, false
+	}
+	if len(s.Actions) == 0 || len(s.Resources) == 0 {
+		return rule, false
+	}
+
+	if len(s.Principals) > 0 {
+		principals := make([]string, len(s.Principals))
+		for i, principal := range s.Principals {
+			principals[i] = patternRegex(string(principal))
+		}
+		rule.Body = append(rule.Body, Expr{Left: call("regex.match", literal(anchored(principals)), ref("input.principal"))})
+	}
+
+	actions := make([]string, len(s.Actions))
+	for i, action := range s.Actions {
+		actions[i] = patternRegex(string(action))
+	}
+	rule.Body = append(rule.Body, Expr{Left: call("regex.match", literal(anchored(actions)), ref("input.action"))})
+
+	resources := make([]string, 0, len(s.Resources))
+	for _, resource := range s.Resources {
+		if resource == "*" {
+			resources = nil
+			break
+		}
+		resources = append(resources, c.resourceRegex(string(resource)))
+	}
+	if resources != nil {
+		rule.Body = append(rule.Body, Expr{Left: call("regex.match", literal(anchored(resources)), ref("resource_path"))})
+	}
+
+	for i, cond := range s.Conditions {
+		exprs, ok := c.compileCondition(cond, path+validator.JSONPointer("conditions", i))
+		if !ok {
+			continue
+		}
+		if exprs[0].Negated {
+			rule.Body = append(rule.Body, Expr{Left: call("has_context", literal(string(cond.Key)))})
+		}
+		rule.Body = append(rule.Body, exprs...)
+	}
+	return rule, true
+}
+
+func (c *compiler) compileCondition(cond policy.Condition, path string) ([]Expr, bool) {
+	key := literal(string(cond.Key))
+	value := cond.Value
+	valuePath := path + "/value"
+
+	switch cond.Operator {
+	case policy.StringEquals, policy.StringNotEquals:
+		s, ok := c.stringValue(value, valuePath)
+		return []Expr{{Negated: cond.Operator == policy.StringNotEquals, Left: call("string_value", key), Op: "==", Right: literal(s)}}, ok
+	case policy.StringEqualsIgnoreCase, policy.StringNotEqualsIgnoreCase:
+		s, ok := c.stringValue(value, valuePath)
+		return []Expr{{
+			Negated: cond.Operator == policy.StringNotEqualsIgnoreCase,
+			Left:    call("lower", call("string_value", key)),
+			Op:      "==",
+			Right:   literal(strings.ToLower(s)),
+		}}, ok
+	case policy.StringLike, policy.StringNotLike:
+		s, ok := c.stringValue(value, valuePath)
+		return []Expr{{
+			Negated: cond.Operator == policy.StringNotLike,
+			Left:    call("regex.match", literal(anchored([]string{patternRegex(s)})), call("string_value", key)),
+		}}, ok
+	case policy.StringMatchesRegex, policy.StringNotMatchesRegex:
+		s, ok := c.stringValue(value, valuePath)
+		if !ok {
+			return nil, false
+		}
+		if _, err := condition.CompileRegex(s); err != nil {
+			c.unsupported(valuePath, "invalid regular expression: %v", err)
+			return nil, false
+		}
+		match := call("regex.match", literal(s), call("string_value", key))
+		if cond.Operator == policy.StringMatchesRegex {
+			return []Expr{{Left: match}}, true
+		}
+		return []Expr{{Left: call("is_string", call("string_value", key))}, {Negated: true, Left: match}}, true
+	case policy.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			c.unsupported(valuePath, "Bool condition value must be a boolean, got %T", value)
+			return nil, false
+		}
+		return []Expr{{Left: Term{Ref: "input.context", Args: []Term{key}}, Op: "==", Right: literal(b)}}, true
+	}
+
+	if op, negated, ok := comparison(cond.Operator, "Numeric"); ok {
+		n, ok := c.numberValue(value, valuePath)
+		return []Expr{{Negated: negated, Left: call("number_value", key), Op: op, Right: literal(n)}}, ok
+	}
+	if op, negated, ok := comparison(cond.Operator, "Date"); ok {
+		ns, ok := c.timeValue(value, valuePath)
+		return []Expr{{Negated: negated, Left: call("time_value", key), Op: op, Right: literal(ns)}}, ok
+	}
+
+	c.unsupported(path+"/operator", "condition operator %q has no Rego equivalent", cond.Operator)
+	return nil, false
+}
+
+var comparisonOperators = map[string]string{
+	"Equals":            "==",
+	"NotEquals":         "==",
+	"LessThan":          "<",
+	"LessThanEquals":    "<=",
+	"GreaterThan":       ">",
+	"GreaterThanEquals": ">=",
+}
+
+func comparison(operator policy.ConditionOperator, family string) (string, bool, bool) {
+	name := strings.TrimPrefix(string(operator), family)
+	if name == string(operator) {
+		return "", false, false
+	}
+	op, ok := comparisonOperators[name]
+	return op, name == "NotEquals", ok
+}
+
+func (c *compiler) stringValue(value interface{}, path string) (string, bool) {
+	s, ok := value.(string)
+	if !ok {
+		c.unsupported(path, "condition value must be a string, got %T", value)
+	}
+	return s, ok
+}
+
+func (c *compiler) numberValue(value interface{}, path string) (json.Number, bool) {
+	var text string
+	switch v := value.(type) {
+	case json.Number:
+		text = string(v)
+	case string:
+		text = strings.TrimSpace(v)
+	case float32, float64:
+		f := reflectFloat(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			c.unsupported(path, "condition value %v is not a finite number", v)
+			return "", false
+		}
+		text = strconv.FormatFloat(f, 'g', -1, 64)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		text = fmt.Sprint(v)
+	}
+	if !jsonNumberPattern.MatchString(text) {
+		c.unsupported(path, "condition value %v is not a number", value)
+		return "", false
+	}
+	return json.Number(text), true
+}
+
+func reflectFloat(v interface{}) float64 {
+	if f, ok := v.(float32); ok {
+		return float64(f)
+	}
+	return v.(float64)
+}
+
+func (c *compiler) timeValue(value interface{}, path string) (int64, bool) {
+	if s, ok := value.(string); ok && condition.IsRelativeTime(s) {
+		c.unsupported(path, "relative date %q is not supported", s)
+		return 0, false
+	}
+	t, err := condition.ParseTime(value, time.Time{})
+	if err != nil {
+		c.unsupported(path, "condition value %v is not a date", value)
+		return 0, false
+	}
+	if t.Year() < 1678 || t.Year() > 2261 {
+		c.unsupported(path, "date %v is out of the nanosecond range", value)
+		return 0, false
+	}
+	return t.UnixNano(), true
+}
+
+func patternRegex(pattern string) string {
+	return strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `.*`)
+}
+
+func (c *compiler) resourceRegex(pattern string) string {
+	if c.separator == "" {
+		return patternRegex(pattern)
+	}
+	sep := regexp.QuoteMeta(c.separator)
+	anySegment := "[^" + sep + "]*"
+
+	var b strings.Builder
+	for _, segment := range policy.SplitResource(policy.Resource(pattern), c.separator) {
+		if segment == "**" {
+			b.WriteString("(?:" + sep + anySegment + ")*")
+			continue
+		}
+		b.WriteString(sep)
+		b.WriteString(strings.ReplaceAll(regexp.QuoteMeta(segment), `\*`, anySegment))
+	}
+	if c.includeDescendants {
+		b.WriteString("(?:" + sep + ".*)?")
+	}
+	return b.String()
+}
+
+func anchored(alternatives []string) string {
+	return "^(?:" + strings.Join(alternatives, "|") + ")$"
+}
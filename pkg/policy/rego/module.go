@@ -0,0 +1,151 @@
+package rego
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+const DefaultPackage = "authz"
+
+// Module is a compiled Rego module whose allow rule mirrors PolicyMatcher.
+type Module struct {
+	Package string
+	// ResourceSeparator is prepended to input.resource before matching.
+	ResourceSeparator string
+	Rules             []Rule
+}
+
+// Rule is the body of one "allowed" or "deny" rule, compiled from a statement.
+type Rule struct {
+	Effect      policy.Effect
+	PolicyID    string
+	StatementID string
+	Body        []Expr
+}
+
+// Expr is one expression of a rule body, optionally negated.
+type Expr struct {
+	Negated bool
+	Left    Term
+	Op      string
+	Right   Term
+}
+
+// Term is a builtin or helper call, a reference into the input, or a literal.
+type Term struct {
+	Call  string
+	Args  []Term
+	Ref   string
+	Value interface{}
+}
+
+func literal(value interface{}) Term {
+	return Term{Value: value}
+}
+
+func call(name string, args ...Term) Term {
+	return Term{Call: name, Args: args}
+}
+
+func ref(name string) Term {
+	return Term{Ref: name}
+}
+
+func (t Term) String() string {
+	switch {
+	case t.Call != "":
+		args := make([]string, len(t.Args))
+		for i, arg := range t.Args {
+			args[i] = arg.String()
+		}
+		return fmt.Sprintf("%s(%s)", t.Call, strings.Join(args, ", "))
+	case t.Ref != "" && len(t.Args) > 0:
+		return fmt.Sprintf("%s[%s]", t.Ref, t.Args[0])
+	case t.Ref != "":
+		return t.Ref
+	}
+	data, err := json.Marshal(t.Value)
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
+
+func (e Expr) String() string {
+	s := e.Left.String()
+	if e.Op != "" {
+		s = fmt.Sprintf("%s %s %s", s, e.Op, e.Right.String())
+	}
+	if e.Negated {
+		return "not " + s
+	}
+	return s
+}
+
+func (m *Module) String() string {
+	var b strings.Builder
+	b.WriteString("# Code generated by go-policy-management. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\nimport rego.v1\n\n", m.Package)
+	b.WriteString("default allow := false\n\nallow if {\n\tallowed\n\tnot deny\n}\n")
+
+	for _, rule := range m.Rules {
+		head := "allowed"
+		if rule.Effect == policy.Deny {
+			head = "deny"
+		}
+		fmt.Fprintf(&b, "\n# policy %q, statement %q\n%s if {\n", rule.PolicyID, rule.StatementID, head)
+		if len(rule.Body) == 0 {
+			b.WriteString("\ttrue\n")
+		}
+		for _, expr := range rule.Body {
+			fmt.Fprintf(&b, "\t%s\n", expr)
+		}
+		b.WriteString("}\n")
+	}
+
+	fmt.Fprintf(&b, "\nresource_path := concat(\"\", [%s, input.resource])\n", literal(m.ResourceSeparator))
+	b.WriteString(helpers)
+	return b.String()
+}
+
+const helpers = `
+has_context(key) if key in object.keys(input.context)
+
+string_value(key) := value if {
+	value := input.context[key]
+	is_string(value)
+}
+
+number_value(key) := value if {
+	value := input.context[key]
+	is_number(value)
+}
+
+number_value(key) := to_number(trim_space(value)) if {
+	value := input.context[key]
+	is_string(value)
+}
+
+time_value(key) := time.parse_rfc3339_ns(input.context[key])
+
+time_value(key) := time.parse_ns("2006-01-02", input.context[key])
+
+time_value(key) := time.parse_ns("2006-01-02T15:04:05", input.context[key])
+
+time_value(key) := time.parse_ns("2006-01-02 15:04:05", input.context[key])
+
+time_value(key) := value * 1000000000 if {
+	value := input.context[key]
+	is_number(value)
+	abs(value) < 1000000000000
+}
+
+time_value(key) := value * 1000000 if {
+	value := input.context[key]
+	is_number(value)
+	abs(value) >= 1000000000000
+}
+`
@@ -0,0 +1,101 @@
+package policy
+
+import (
+	"strings"
+)
+
+type ResourceNameSyntax struct {
+	SegmentSeparator   string
+	QualifierSeparator string
+}
+
+var DefaultResourceNameSyntax = ResourceNameSyntax{
+	SegmentSeparator:   "/",
+	QualifierSeparator: ":",
+}
+
+type ResourceSegment struct {
+	Type string
+	ID   string
+}
+
+func (s ResourceSegment) String(syntax ResourceNameSyntax) string {
+	if s.Type == "" {
+		return s.ID
+	}
+	return s.Type + syntax.QualifierSeparator + s.ID
+}
+
+// ResourceName is a hierarchical resource such as "org:acme/project:42".
+type ResourceName struct {
+	Segments []ResourceSegment
+	Syntax   ResourceNameSyntax
+}
+
+func ParseResourceName(resource Resource, syntax ResourceNameSyntax) ResourceName {
+	name := ResourceName{Syntax: syntax}
+	for _, raw := range SplitResource(resource, syntax.SegmentSeparator) {
+		segment := ResourceSegment{ID: raw}
+		if syntax.QualifierSeparator != "" {
+			if i := strings.Index(raw, syntax.QualifierSeparator); i >= 0 {
+				segment = ResourceSegment{Type: raw[:i], ID: raw[i+len(syntax.QualifierSeparator):]}
+			}
+		}
+		name.Segments = append(name.Segments, segment)
+	}
+	return name
+}
+
+// SplitResource splits a resource into its raw segments.
+func SplitResource(resource Resource, separator string) []string {
+	if separator == "" {
+		return []string{string(resource)}
+	}
+	return strings.Split(string(resource), separator)
+}
+
+func (n ResourceName) String() string {
+	parts := make([]string, len(n.Segments))
+	for i, segment := range n.Segments {
+		parts[i] = segment.String(n.Syntax)
+	}
+	return strings.Join(parts, n.Syntax.SegmentSeparator)
+}
+
+func (n ResourceName) Resource() Resource {
+	return Resource(n.String())
+}
+
+func (n ResourceName) Type() string {
+	if len(n.Segments) == 0 {
+		return ""
+	}
+	return n.Segments[len(n.Segments)-1].Type
+}
+
+func (n ResourceName) Parent() (ResourceName, bool) {
+	if len(n.Segments) <= 1 {
+		return ResourceName{}, false
+	}
+	return ResourceName{Segments: n.Segments[:len(n.Segments)-1], Syntax: n.Syntax}, true
+}
+
+func (n ResourceName) Ancestors() []ResourceName {
+	var ancestors []ResourceName
+	for parent, ok := n.Parent(); ok; parent, ok = parent.Parent() {
+		ancestors = append(ancestors, parent)
+	}
+	return ancestors
+}
+
+func (n ResourceName) IsAncestorOf(other ResourceName) bool {
+	if len(n.Segments) >= len(other.Segments) {
+		return false
+	}
+	for i, segment := range n.Segments {
+		if segment != other.Segments[i] {
+			return false
+		}
+	}
+	return true
+}
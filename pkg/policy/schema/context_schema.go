@@ -0,0 +1,175 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+)
+
+// ContextSchema declares the type of each request context key.
+type ContextSchema struct {
+	// Strict rejects condition keys in policies that the schema does not declare.
+	Strict bool
+
+	mu   sync.RWMutex
+	keys map[policy.ConditionKey]KeyDefinition
+}
+
+func NewContextSchema(definitions ...KeyDefinition) (*ContextSchema, error) {
+	s := &ContextSchema{
+		keys: make(map[policy.ConditionKey]KeyDefinition),
+	}
+	if err := s.Register(definitions...); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *ContextSchema) Register(definitions ...KeyDefinition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, definition := range definitions {
+		if definition.Key == "" {
+			return fmt.Errorf("context key name is required")
+		}
+		if !definition.Type.IsValid() {
+			return fmt.Errorf("context key %q has unknown type %q", definition.Key, definition.Type)
+		}
+		if definition.ElementType != "" && (definition.Type != TypeList || !definition.ElementType.IsValid() || definition.ElementType == TypeList) {
+			return fmt.Errorf("context key %q has invalid element type %q", definition.Key, definition.ElementType)
+		}
+		if _, exists := s.keys[definition.Key]; exists {
+			return fmt.Errorf("context key %q is already declared", definition.Key)
+		}
+		s.keys[definition.Key] = definition
+	}
+	return nil
+}
+
+func (s *ContextSchema) Lookup(key policy.ConditionKey) (KeyDefinition, bool) {
+	if s == nil {
+		return KeyDefinition{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	definition, ok := s.keys[key]
+	return definition, ok
+}
+
+func (s *ContextSchema) Keys() []KeyDefinition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	definitions := make([]KeyDefinition, 0, len(s.keys))
+	for _, definition := range s.keys {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Key < definitions[j].Key })
+	return definitions
+}
+
+// Coerce validates a request context and returns a copy with declared keys coerced to their types.
+func (s *ContextSchema) Coerce(context map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(context))
+	for key, value := range context {
+		result[key] = value
+	}
+
+	var errs ContextErrors
+	for _, definition := range s.Keys() {
+		key := string(definition.Key)
+		value, exists := context[key]
+		if !exists || value == nil {
+			if definition.Required {
+				errs = append(errs, ContextError{Key: key, Message: "is required"})
+			}
+			continue
+		}
+		coerced, err := CoerceValue(value, definition.Type, definition.ElementType)
+		if err != nil {
+			errs = append(errs, ContextError{Key: key, Message: err.Error()})
+			continue
+		}
+		result[key] = coerced
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return result, nil
+}
+
+func CoerceValue(value interface{}, keyType, elementType KeyType) (interface{}, error) {
+	switch keyType {
+	case TypeString:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case TypeNumber:
+		if _, err := condition.ParseNumber(value); err == nil {
+			if s, ok := value.(string); ok {
+				return json.Number(strings.TrimSpace(s)), nil
+			}
+			return value, nil
+		}
+	case TypeDate:
+		if t, err := condition.ParseAbsoluteTime(value); err == nil {
+			return t, nil
+		}
+	case TypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		}
+	case TypeIP:
+		if s, ok := value.(string); ok {
+			if ip := net.ParseIP(strings.TrimSpace(s)); ip != nil {
+				return ip.String(), nil
+			}
+		}
+	case TypeList:
+		items, ok := listItems(value)
+		if !ok {
+			break
+		}
+		if elementType == "" {
+			return items, nil
+		}
+		for i, item := range items {
+			coerced, err := CoerceValue(item, elementType, "")
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %v", i, err)
+			}
+			items[i] = coerced
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("expected %s, got %T %v", keyType, value, value)
+}
+
+func listItems(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return append([]interface{}(nil), v...), true
+	case []string:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		return items, true
+	}
+	return nil, false
+}
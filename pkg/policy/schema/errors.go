@@ -0,0 +1,25 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ContextError struct {
+	Key     string
+	Message string
+}
+
+func (e ContextError) Error() string {
+	return fmt.Sprintf("context key %q: %s", e.Key, e.Message)
+}
+
+type ContextErrors []ContextError
+
+func (e ContextErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,62 @@
+package schema
+
+import "github.com/CarlosHe/go-policy-management/pkg/policy"
+
+type KeyType string
+
+const (
+	TypeString KeyType = "string"
+	TypeNumber KeyType = "number"
+	TypeDate   KeyType = "date"
+	TypeBool   KeyType = "bool"
+	TypeList   KeyType = "list"
+	TypeIP     KeyType = "ip"
+)
+
+var keyTypes = map[KeyType]bool{
+	TypeString: true,
+	TypeNumber: true,
+	TypeDate:   true,
+	TypeBool:   true,
+	TypeList:   true,
+	TypeIP:     true,
+}
+
+func (t KeyType) IsValid() bool {
+	return keyTypes[t]
+}
+
+// SupportsFamily reports whether the operator family applies to values of this type.
+func (t KeyType) SupportsFamily(family policy.OperatorFamily) bool {
+	switch family {
+	case policy.StringFamily, policy.VersionFamily:
+		return t == TypeString || t == TypeIP
+	case policy.NumericFamily:
+		return t == TypeNumber
+	case policy.DateFamily, policy.ScheduleFamily:
+		return t == TypeDate
+	case policy.BoolFamily:
+		return t == TypeBool
+	case policy.DurationFamily:
+		return t == TypeString
+	case policy.ListFamily:
+		return t == TypeList
+	}
+	return true
+}
+
+type KeyDefinition struct {
+	Key         policy.ConditionKey `json:"key"`
+	Type        KeyType             `json:"type"`
+	ElementType KeyType             `json:"element_type,omitempty"`
+	Required    bool                `json:"required,omitempty"`
+	Description string              `json:"description,omitempty"`
+}
+
+// SupportsFamily also requires string elements for the list operators.
+func (d KeyDefinition) SupportsFamily(family policy.OperatorFamily) bool {
+	if family == policy.ListFamily && d.ElementType != "" && d.ElementType != TypeString && d.ElementType != TypeIP {
+		return false
+	}
+	return d.Type.SupportsFamily(family)
+}
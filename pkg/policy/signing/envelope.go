@@ -0,0 +1,75 @@
+package signing
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+// Envelope carries a policy and its signatures in the JWS general JSON serialization.
+type Envelope struct {
+	Payload    string      `json:"payload"`
+	Signatures []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	Protected string `json:"protected"`
+	Signature string `json:"signature"`
+}
+
+// Seal creates an envelope for the policy signed with key under kid.
+func Seal(p policy.Policy, kid string, key crypto.Signer) (Envelope, error) {
+	payload, err := p.CanonicalJSON()
+	if err != nil {
+		return Envelope{}, err
+	}
+	e := Envelope{Payload: encoding.EncodeToString(payload)}
+	if err := e.AddSignature(kid, key); err != nil {
+		return Envelope{}, err
+	}
+	return e, nil
+}
+
+// AddSignature adds a signature, e.g. while rotating keys.
+func (e *Envelope) AddSignature(kid string, key crypto.Signer) error {
+	protected, signature, err := sign(e.Payload, kid, key)
+	if err != nil {
+		return err
+	}
+	e.Signatures = append(e.Signatures, Signature{Protected: protected, Signature: signature})
+	return nil
+}
+
+// Open verifies the envelope and returns its policy.
+func (e Envelope) Open(keyring *Keyring) (policy.Policy, error) {
+	if len(e.Signatures) == 0 {
+		return policy.Policy{}, ErrUnsigned
+	}
+	trusted := 0
+	for i, s := range e.Signatures {
+		err := verify(e.Payload, s.Protected, s.Signature, keyring)
+		if errors.Is(err, ErrUnknownKey) {
+			continue
+		}
+		if err != nil {
+			return policy.Policy{}, fmt.Errorf("signature %d: %w", i, err)
+		}
+		trusted++
+	}
+	if trusted == 0 {
+		return policy.Policy{}, ErrUnknownKey
+	}
+
+	payload, err := encoding.DecodeString(e.Payload)
+	if err != nil {
+		return policy.Policy{}, fmt.Errorf("%w: malformed payload", ErrInvalidSignature)
+	}
+	var p policy.Policy
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return policy.Policy{}, fmt.Errorf("signed payload: %v", err)
+	}
+	return p, nil
+}
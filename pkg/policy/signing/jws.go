@@ -0,0 +1,155 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+const (
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmES256 = "ES256"
+)
+
+var (
+	ErrUnsigned         = errors.New("policy is not signed")
+	ErrUnknownKey       = errors.New("no signature by a trusted key")
+	ErrInvalidSignature = errors.New("invalid policy signature")
+)
+
+var encoding = base64.RawURLEncoding
+
+// Header is the JWS protected header of a policy signature.
+type Header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// Sign returns a detached compact JWS over the canonical encoding of the policy.
+func Sign(p policy.Policy, kid string, key crypto.Signer) (string, error) {
+	payload, err := p.CanonicalJSON()
+	if err != nil {
+		return "", err
+	}
+	protected, signature, err := sign(encoding.EncodeToString(payload), kid, key)
+	if err != nil {
+		return "", err
+	}
+	return protected + ".." + signature, nil
+}
+
+// Verify checks a detached JWS produced by Sign against the policy.
+func Verify(p policy.Policy, jws string, keyring *Keyring) error {
+	parts := strings.Split(jws, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return fmt.Errorf("%w: expected a detached compact JWS", ErrInvalidSignature)
+	}
+	payload, err := p.CanonicalJSON()
+	if err != nil {
+		return err
+	}
+	return verify(encoding.EncodeToString(payload), parts[0], parts[2], keyring)
+}
+
+func sign(payload, kid string, key crypto.Signer) (string, string, error) {
+	if kid == "" {
+		return "", "", fmt.Errorf("key ID is required")
+	}
+	alg, err := algorithmForPublicKey(key.Public())
+	if err != nil {
+		return "", "", err
+	}
+	header, err := json.Marshal(Header{Algorithm: alg, KeyID: kid})
+	if err != nil {
+		return "", "", err
+	}
+	protected := encoding.EncodeToString(header)
+	input := []byte(protected + "." + payload)
+
+	var signature []byte
+	switch key.Public().(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(input)
+		der, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if err != nil {
+			return "", "", err
+		}
+		if signature, err = rawECDSASignature(der); err != nil {
+			return "", "", err
+		}
+	default:
+		if signature, err = key.Sign(rand.Reader, input, crypto.Hash(0)); err != nil {
+			return "", "", err
+		}
+	}
+	return protected, encoding.EncodeToString(signature), nil
+}
+
+func rawECDSASignature(der []byte) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil || len(rest) > 0 {
+		return nil, fmt.Errorf("malformed ECDSA signature from signer")
+	}
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.BitLen() > 256 || sig.S.BitLen() > 256 {
+		return nil, fmt.Errorf("ECDSA signature from signer is out of range for P-256")
+	}
+	signature := make([]byte, 64)
+	sig.R.FillBytes(signature[:32])
+	sig.S.FillBytes(signature[32:])
+	return signature, nil
+}
+
+func verify(payload, protected, signature string, keyring *Keyring) error {
+	headerJSON, err := encoding.DecodeString(protected)
+	if err != nil {
+		return fmt.Errorf("%w: malformed header", ErrInvalidSignature)
+	}
+	var header Header
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return fmt.Errorf("%w: malformed header", ErrInvalidSignature)
+	}
+	sig, err := encoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidSignature)
+	}
+
+	key, ok := keyring.Lookup(header.KeyID)
+	if !ok {
+		return fmt.Errorf("%w: unknown key %q", ErrUnknownKey, header.KeyID)
+	}
+	if alg, _ := algorithmForPublicKey(key); alg != header.Algorithm {
+		return fmt.Errorf("%w: algorithm %q does not match key %q", ErrInvalidSignature, header.Algorithm, header.KeyID)
+	}
+
+	input := []byte(protected + "." + payload)
+	valid := false
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(k, input, sig)
+	case *ecdsa.PublicKey:
+		if len(sig) == 64 {
+			digest := sha256.Sum256(input)
+			r := new(big.Int).SetBytes(sig[:32])
+			s := new(big.Int).SetBytes(sig[32:])
+			valid = ecdsa.Verify(k, digest[:], r, s)
+		}
+	}
+	if !valid {
+		return fmt.Errorf("%w: key %q", ErrInvalidSignature, header.KeyID)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sync"
+)
+
+// Keyring holds the public keys trusted to sign policies, by key ID.
+type Keyring struct {
+	mu   sync.RWMutex
+	keys map[string]crypto.PublicKey
+}
+
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[string]crypto.PublicKey)}
+}
+
+// Add trusts an ed25519.PublicKey or a P-256 *ecdsa.PublicKey under kid.
+func (k *Keyring) Add(kid string, key crypto.PublicKey) error {
+	if kid == "" {
+		return fmt.Errorf("key ID is required")
+	}
+	if _, err := algorithmForPublicKey(key); err != nil {
+		return err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys[kid] = key
+	return nil
+}
+
+// AddPEM adds a PKIX public key in a PEM "PUBLIC KEY" block.
+func (k *Keyring) AddPEM(kid string, data []byte) error {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return fmt.Errorf("key %s: expected a PEM PUBLIC KEY block", kid)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("key %s: %v", kid, err)
+	}
+	return k.Add(kid, key)
+}
+
+func (k *Keyring) Remove(kid string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.keys, kid)
+}
+
+func (k *Keyring) Lookup(kid string) (crypto.PublicKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+func algorithmForPublicKey(key crypto.PublicKey) (string, error) {
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return AlgorithmEdDSA, nil
+	case *ecdsa.PublicKey:
+		if k.Curve == elliptic.P256() {
+			return AlgorithmES256, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported key type %T, expected Ed25519 or ECDSA P-256", key)
+}
@@ -0,0 +1,96 @@
+package signing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+)
+
+type loaderOptions struct {
+	allowUnsigned bool
+}
+
+type LoaderOption func(*loaderOptions)
+
+// AllowUnsigned accepts plain policy documents next to envelopes.
+func AllowUnsigned() LoaderOption {
+	return func(o *loaderOptions) {
+		o.allowUnsigned = true
+	}
+}
+
+// Loader decodes policies signed by a key in its keyring.
+type Loader struct {
+	keyring *Keyring
+	options loaderOptions
+}
+
+func NewLoader(keyring *Keyring, opts ...LoaderOption) *Loader {
+	l := &Loader{keyring: keyring}
+	for _, opt := range opts {
+		opt(&l.options)
+	}
+	return l
+}
+
+// Load accepts a single envelope or a JSON array of envelopes.
+func (l *Loader) Load(data []byte) ([]policy.Policy, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] != '[' {
+		p, err := l.loadEntry(data)
+		if err != nil {
+			return nil, err
+		}
+		return []policy.Policy{p}, nil
+	}
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	policies := make([]policy.Policy, 0, len(entries))
+	for i, entry := range entries {
+		p, err := l.loadEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("policy %d: %w", i, err)
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+// LoadEvaluator loads verified policies into an evaluator created by f.
+func (l *Loader) LoadEvaluator(data []byte, f factory.IEvaluatorFactory) (evaluator.IPolicyEvaluator, error) {
+	policies, err := l.Load(data)
+	if err != nil {
+		return nil, err
+	}
+	return f.CreatePolicyEvaluator(policies...), nil
+}
+
+func (l *Loader) loadEntry(data []byte) (policy.Policy, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return policy.Policy{}, err
+	}
+	_, hasPayload := fields["payload"]
+	_, hasSignatures := fields["signatures"]
+	if !hasPayload && !hasSignatures {
+		if !l.options.allowUnsigned {
+			return policy.Policy{}, ErrUnsigned
+		}
+		var p policy.Policy
+		err := json.Unmarshal(data, &p)
+		return p, err
+	}
+
+	var e Envelope
+	if err := json.Unmarshal(data, &e); err != nil {
+		return policy.Policy{}, err
+	}
+	return e.Open(l.keyring)
+}
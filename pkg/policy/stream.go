@@ -0,0 +1,322 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// PolicyEntryError reports a stream entry that could not be decoded or failed validation.
+type PolicyEntryError struct {
+	Index int
+	Err   error
+}
+
+func (e *PolicyEntryError) Error() string {
+	return fmt.Sprintf("policy %d: %v", e.Index, e.Err)
+}
+
+func (e *PolicyEntryError) Unwrap() error {
+	return e.Err
+}
+
+type streamOptions struct {
+	decode   func([]byte) (Policy, error)
+	validate func(Policy) error
+	onError  func(*PolicyEntryError)
+}
+
+type StreamOption func(*streamOptions)
+
+// WithStreamValidation checks each policy as it is read.
+func WithStreamValidation(validate func(Policy) error) StreamOption {
+	return func(o *streamOptions) {
+		o.validate = validate
+	}
+}
+
+// WithStreamMigration decodes each entry with migrate, such as migration.Registry.StreamMigration.
+func WithStreamMigration(migrate func([]byte) (Policy, error)) StreamOption {
+	return func(o *streamOptions) {
+		o.decode = migrate
+	}
+}
+
+// ContinueOnError passes invalid entries to report and skips them.
+func ContinueOnError(report func(*PolicyEntryError)) StreamOption {
+	return func(o *streamOptions) {
+		o.onError = report
+	}
+}
+
+type streamState int
+
+const (
+	streamNotStarted streamState = iota
+	streamInArray
+	streamDone
+)
+
+// PolicyDecoder reads policies one at a time from a JSON policy list or array.
+type PolicyDecoder struct {
+	decoder *json.Decoder
+	list    bool
+	options streamOptions
+	state   streamState
+	index   int
+	err     error
+}
+
+// NewJSONListDecoder reads the format of FromJSONList, {"policies": [...]}.
+func NewJSONListDecoder(r io.Reader, opts ...StreamOption) *PolicyDecoder {
+	return newPolicyDecoder(r, true, opts)
+}
+
+// NewJSONArrayDecoder reads the format of FromJSONArray, [...].
+func NewJSONArrayDecoder(r io.Reader, opts ...StreamOption) *PolicyDecoder {
+	return newPolicyDecoder(r, false, opts)
+}
+
+func newPolicyDecoder(r io.Reader, list bool, opts []StreamOption) *PolicyDecoder {
+	d := &PolicyDecoder{decoder: json.NewDecoder(r), list: list}
+	for _, opt := range opts {
+		opt(&d.options)
+	}
+	return d
+}
+
+// Next returns the next policy, or io.EOF after the last one.
+func (d *PolicyDecoder) Next() (Policy, error) {
+	for {
+		p, err := d.next()
+		var entryErr *PolicyEntryError
+		if d.options.onError != nil && errors.As(err, &entryErr) {
+			d.options.onError(entryErr)
+			continue
+		}
+		return p, err
+	}
+}
+
+// Each calls fn with each policy and its index, stopping at the first error.
+func (d *PolicyDecoder) Each(fn func(index int, p Policy) error) error {
+	for {
+		p, err := d.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(d.index-1, p); err != nil {
+			return err
+		}
+	}
+}
+
+func (d *PolicyDecoder) next() (Policy, error) {
+	if d.err != nil {
+		return Policy{}, d.err
+	}
+	if d.state == streamNotStarted {
+		if err := d.start(); err != nil {
+			return Policy{}, d.fail(err)
+		}
+	}
+	if d.state == streamDone {
+		return Policy{}, io.EOF
+	}
+
+	if !d.decoder.More() {
+		if err := d.finish(); err != nil {
+			return Policy{}, d.fail(err)
+		}
+		return Policy{}, io.EOF
+	}
+
+	var raw json.RawMessage
+	if err := d.decoder.Decode(&raw); err != nil {
+		return Policy{}, d.fail(err)
+	}
+	index := d.index
+	d.index++
+
+	p, err := d.decode(raw)
+	if err != nil {
+		return Policy{}, &PolicyEntryError{Index: index, Err: err}
+	}
+	if d.options.validate != nil {
+		if err := d.options.validate(p); err != nil {
+			return Policy{}, &PolicyEntryError{Index: index, Err: err}
+		}
+	}
+	return p, nil
+}
+
+func (d *PolicyDecoder) decode(raw json.RawMessage) (Policy, error) {
+	if d.options.decode != nil {
+		return d.options.decode(raw)
+	}
+	var p Policy
+	err := json.Unmarshal(raw, &p)
+	return p, err
+}
+
+func (d *PolicyDecoder) start() error {
+	if !d.list {
+		d.state = streamInArray
+		return d.expectDelim('[')
+	}
+	if err := d.expectDelim('{'); err != nil {
+		return err
+	}
+	found, err := d.skipTo("policies")
+	if err != nil {
+		return err
+	}
+	if !found {
+		d.state = streamDone
+		return d.expectDelim('}')
+	}
+	offset := d.decoder.InputOffset()
+	token, err := d.decoder.Token()
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	switch token {
+	case nil:
+		d.state = streamDone
+		return d.closeList()
+	case json.Delim('['):
+		d.state = streamInArray
+		return nil
+	}
+	return fmt.Errorf("json: expected %q at offset %d, got %v", json.Delim('['), offset, token)
+}
+
+func (d *PolicyDecoder) finish() error {
+	d.state = streamDone
+	if err := d.expectDelim(']'); err != nil {
+		return err
+	}
+	if !d.list {
+		return nil
+	}
+	return d.closeList()
+}
+
+func (d *PolicyDecoder) closeList() error {
+	duplicate, err := d.skipTo("policies")
+	if err != nil {
+		return err
+	}
+	if duplicate {
+		return errors.New(`json: duplicate key "policies"`)
+	}
+	return d.expectDelim('}')
+}
+
+func (d *PolicyDecoder) skipTo(key string) (bool, error) {
+	for d.decoder.More() {
+		token, err := d.decoder.Token()
+		if err != nil {
+			return false, err
+		}
+		if name, _ := token.(string); name == key {
+			return true, nil
+		}
+		var skipped json.RawMessage
+		if err := d.decoder.Decode(&skipped); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+func (d *PolicyDecoder) expectDelim(delim json.Delim) error {
+	offset := d.decoder.InputOffset()
+	token, err := d.decoder.Token()
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("json: expected %q at offset %d, got %v", delim, offset, token)
+	}
+	return nil
+}
+
+func (d *PolicyDecoder) fail(err error) error {
+	d.err = err
+	return err
+}
+
+// PolicyEncoder writes policies one at a time as a JSON policy list or array.
+type PolicyEncoder struct {
+	w      io.Writer
+	list   bool
+	count  int
+	closed bool
+}
+
+// NewJSONListEncoder writes the format of ToJSONList, {"policies":[...]}.
+func NewJSONListEncoder(w io.Writer) *PolicyEncoder {
+	return &PolicyEncoder{w: w, list: true}
+}
+
+// NewJSONArrayEncoder writes a JSON array of policies.
+func NewJSONArrayEncoder(w io.Writer) *PolicyEncoder {
+	return &PolicyEncoder{w: w}
+}
+
+func (e *PolicyEncoder) Encode(p Policy) error {
+	if e.closed {
+		return errors.New("policy encoder is closed")
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("policy %d: %v", e.count, err)
+	}
+	separator := ","
+	if e.count == 0 {
+		separator = e.opening()
+	}
+	if _, err := io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(data); err != nil {
+		return err
+	}
+	e.count++
+	return nil
+}
+
+// Close terminates the document; it does not close the underlying writer.
+func (e *PolicyEncoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	closing := "]"
+	if e.list {
+		closing = "]}"
+	}
+	if e.count == 0 {
+		closing = e.opening() + closing
+	}
+	_, err := io.WriteString(e.w, closing)
+	return err
+}
+
+func (e *PolicyEncoder) opening() string {
+	if e.list {
+		return `{"policies":[`
+	}
+	return "["
+}
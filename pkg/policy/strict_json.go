@@ -0,0 +1,296 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// StrictJSONError reports why strict decoding rejected a document, with its path and position.
+type StrictJSONError struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *StrictJSONError) Error() string {
+	return fmt.Sprintf("json: %s at %s (line %d, column %d)", e.Message, e.Path, e.Line, e.Column)
+}
+
+type jsonShapeKind int
+
+const (
+	anyShape jsonShapeKind = iota
+	stringShape
+	timeShape
+	arrayShape
+	objectShape
+)
+
+type jsonShape struct {
+	kind           jsonShapeKind
+	fields         map[string]*jsonShape
+	elem           *jsonShape
+	operatorValues map[string]*jsonShape
+}
+
+var (
+	anyJSONShape    = &jsonShape{kind: anyShape}
+	stringJSONShape = &jsonShape{kind: stringShape}
+	timeJSONShape   = &jsonShape{kind: timeShape}
+
+	conditionJSONShape = &jsonShape{kind: objectShape, fields: map[string]*jsonShape{
+		"operator": stringJSONShape,
+		"key":      stringJSONShape,
+		"value":    anyJSONShape,
+	}, operatorValues: map[string]*jsonShape{
+		string(ScheduleTimeOfDay): {kind: anyShape, fields: map[string]*jsonShape{
+			"start": anyJSONShape, "end": anyJSONShape, "timezone": anyJSONShape,
+		}},
+		string(ScheduleDayOfWeek): {kind: anyShape, fields: map[string]*jsonShape{
+			"days": anyJSONShape, "timezone": anyJSONShape,
+		}},
+		string(ScheduleCron): {kind: anyShape, fields: map[string]*jsonShape{
+			"cron": anyJSONShape, "timezone": anyJSONShape,
+		}},
+	}}
+	statementJSONShape = &jsonShape{kind: objectShape, fields: map[string]*jsonShape{
+		"id":         stringJSONShape,
+		"effect":     stringJSONShape,
+		"principals": {kind: arrayShape, elem: stringJSONShape},
+		"actions":    {kind: arrayShape, elem: stringJSONShape},
+		"resources":  {kind: arrayShape, elem: stringJSONShape},
+		"conditions": {kind: arrayShape, elem: conditionJSONShape},
+	}}
+	policyJSONShape = &jsonShape{kind: objectShape, fields: map[string]*jsonShape{
+		"version":     stringJSONShape,
+		"id":          stringJSONShape,
+		"name":        stringJSONShape,
+		"description": stringJSONShape,
+		"statements":  {kind: arrayShape, elem: statementJSONShape},
+		"created_at":  timeJSONShape,
+		"updated_at":  timeJSONShape,
+		"template": {kind: objectShape, fields: map[string]*jsonShape{
+			"id":         stringJSONShape,
+			"version":    stringJSONShape,
+			"parameters": anyJSONShape,
+		}},
+	}}
+	policyArrayJSONShape = &jsonShape{kind: arrayShape, elem: policyJSONShape}
+	policyListJSONShape  = &jsonShape{kind: objectShape, fields: map[string]*jsonShape{
+		"policies": policyArrayJSONShape,
+	}}
+)
+
+// FromJSONStrict decodes a policy, rejecting unknown fields, duplicate keys and mistyped values.
+func FromJSONStrict(jsonStr string) (Policy, error) {
+	var p Policy
+	err := unmarshalStrict([]byte(jsonStr), policyJSONShape, &p)
+	return p, err
+}
+
+func FromJSONArrayStrict(jsonStr string) ([]Policy, error) {
+	var p []Policy
+	err := unmarshalStrict([]byte(jsonStr), policyArrayJSONShape, &p)
+	return p, err
+}
+
+func FromJSONListStrict(jsonStr string) ([]Policy, error) {
+	var pl PolicyList
+	err := unmarshalStrict([]byte(jsonStr), policyListJSONShape, &pl)
+	return pl.Policies, err
+}
+
+func unmarshalStrict(data []byte, shape *jsonShape, target interface{}) error {
+	d := newStrictDecoder(data, 0)
+	if _, err := d.walk(shape, "$"); err != nil {
+		return err
+	}
+	offset := d.offset()
+	if _, err := d.dec.Token(); err != io.EOF {
+		return d.errorAt(offset, "$", "unexpected data after top-level value")
+	}
+	return json.Unmarshal(data, target)
+}
+
+type strictDecoder struct {
+	data []byte
+	base int64
+	dec  *json.Decoder
+}
+
+func newStrictDecoder(data []byte, base int64) *strictDecoder {
+	d := &strictDecoder{data: data, base: base, dec: json.NewDecoder(bytes.NewReader(data[base:]))}
+	d.dec.UseNumber()
+	return d
+}
+
+func (d *strictDecoder) offset() int64 {
+	return d.base + d.dec.InputOffset()
+}
+
+func (d *strictDecoder) walk(shape *jsonShape, path string) (json.Token, error) {
+	start := d.offset()
+	token, err := d.dec.Token()
+	if err != nil {
+		return nil, d.syntaxError(err, start, path)
+	}
+
+	switch shape.kind {
+	case stringShape:
+		if _, ok := token.(string); !ok {
+			return nil, d.errorAt(start, path, fmt.Sprintf("expected string, got %s", tokenKind(token)))
+		}
+	case timeShape:
+		s, ok := token.(string)
+		if !ok {
+			return nil, d.errorAt(start, path, fmt.Sprintf("expected timestamp, got %s", tokenKind(token)))
+		}
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return nil, d.errorAt(start, path, fmt.Sprintf("invalid RFC 3339 timestamp %q", s))
+		}
+	case arrayShape:
+		if token == nil {
+			return token, nil
+		}
+		if token != json.Delim('[') {
+			return nil, d.errorAt(start, path, fmt.Sprintf("expected array, got %s", tokenKind(token)))
+		}
+		return token, d.elements(shape.elem, path)
+	case objectShape:
+		if token != json.Delim('{') {
+			return nil, d.errorAt(start, path, fmt.Sprintf("expected object, got %s", tokenKind(token)))
+		}
+		return token, d.members(shape, path)
+	default:
+		switch token {
+		case json.Delim('{'):
+			return token, d.members(shape, path)
+		case json.Delim('['):
+			return token, d.elements(anyJSONShape, path)
+		}
+	}
+	return token, nil
+}
+
+func (d *strictDecoder) elements(elem *jsonShape, path string) error {
+	for i := 0; d.dec.More(); i++ {
+		if _, err := d.walk(elem, path+"["+strconv.Itoa(i)+"]"); err != nil {
+			return err
+		}
+	}
+	return d.closing(path)
+}
+
+func (d *strictDecoder) members(shape *jsonShape, path string) error {
+	seen := make(map[string]bool)
+	var operator string
+	valueStart := int64(-1)
+	for d.dec.More() {
+		keyStart := d.offset()
+		keyToken, err := d.dec.Token()
+		if err != nil {
+			return d.syntaxError(err, keyStart, path)
+		}
+		key := keyToken.(string)
+		fieldPath := path + "." + key
+		if seen[key] {
+			return d.errorAt(keyStart, fieldPath, fmt.Sprintf("duplicate key %q", key))
+		}
+		seen[key] = true
+		field := anyJSONShape
+		if shape.fields != nil {
+			var ok bool
+			if field, ok = shape.fields[key]; !ok {
+				return d.errorAt(keyStart, fieldPath, fmt.Sprintf("unknown field %q", key))
+			}
+		}
+		if key == "value" {
+			valueStart = d.offset()
+		}
+		token, err := d.walk(field, fieldPath)
+		if err != nil {
+			return err
+		}
+		if key == "operator" {
+			operator, _ = token.(string)
+		}
+	}
+	if err := d.closing(path); err != nil {
+		return err
+	}
+	if valueShape, ok := shape.operatorValues[operator]; ok && valueStart >= 0 {
+		_, err := newStrictDecoder(d.data, d.skipSeparators(valueStart)).walk(valueShape, path+".value")
+		return err
+	}
+	return nil
+}
+
+func (d *strictDecoder) closing(path string) error {
+	offset := d.offset()
+	if _, err := d.dec.Token(); err != nil {
+		return d.syntaxError(err, offset, path)
+	}
+	return nil
+}
+
+func (d *strictDecoder) syntaxError(err error, offset int64, path string) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return d.errorAt(d.base+syntaxErr.Offset, path, syntaxErr.Error())
+	}
+	if err == io.EOF {
+		return d.errorAt(int64(len(d.data)), path, "unexpected end of input")
+	}
+	return d.errorAt(offset, path, err.Error())
+}
+
+func (d *strictDecoder) skipSeparators(offset int64) int64 {
+	for offset < int64(len(d.data)) {
+		c := d.data[offset]
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' && c != ':' {
+			break
+		}
+		offset++
+	}
+	return offset
+}
+
+func (d *strictDecoder) errorAt(offset int64, path, message string) error {
+	offset = d.skipSeparators(offset)
+	line, column := 1, 1
+	for _, c := range d.data[:offset] {
+		if c == '\n' {
+			line++
+			column = 1
+		} else if utf8.RuneStart(c) {
+			column++
+		}
+	}
+	return &StrictJSONError{Path: path, Line: line, Column: column, Message: message}
+}
+
+func tokenKind(token json.Token) string {
+	switch v := token.(type) {
+	case json.Delim:
+		if v == '{' {
+			return "object"
+		}
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", token)
+}
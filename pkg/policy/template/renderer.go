@@ -0,0 +1,443 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+const (
+	globChars  = "*?[{"
+	regexChars = `\.+*?()|[]{}^$`
+)
+
+var (
+	parameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	placeholderPattern   = regexp.MustCompile(`\$\$\{|\$\{([^}]*)\}`)
+	wholeValuePattern    = regexp.MustCompile(`^\$\{([^}]*)\}$`)
+)
+
+type rendererOptions struct {
+	clock             condition.Clock
+	resourceSeparator string
+	validator         validator.IPolicyValidator
+}
+
+type RendererOption func(*rendererOptions)
+
+// WithClock sets the clock used for the timestamps of rendered policies.
+func WithClock(clock condition.Clock) RendererOption {
+	return func(o *rendererOptions) {
+		o.clock = clock
+	}
+}
+
+// WithResourceSeparator sets the resource separator substituted values may not contain.
+func WithResourceSeparator(separator string) RendererOption {
+	return func(o *rendererOptions) {
+		o.resourceSeparator = separator
+	}
+}
+
+// WithValidator sets the validator rendered policies must pass.
+func WithValidator(v validator.IPolicyValidator) RendererOption {
+	return func(o *rendererOptions) {
+		o.validator = v
+	}
+}
+
+// Renderer renders templates into policies through a policy factory.
+type Renderer struct {
+	factory factory.IPolicyFactory
+	options rendererOptions
+}
+
+func NewRenderer(opts ...RendererOption) *Renderer {
+	return NewRendererWithFactory(factory.NewPolicyFactory(), opts...)
+}
+
+func NewRendererWithFactory(f factory.IPolicyFactory, opts ...RendererOption) *Renderer {
+	r := &Renderer{
+		factory: f,
+		options: rendererOptions{
+			clock:             condition.NewSystemClock(),
+			resourceSeparator: policy.DefaultResourceNameSyntax.SegmentSeparator,
+			validator:         validator.NewDefaultValidator(),
+		},
+	}
+	for _, opt := range opts {
+		opt(&r.options)
+	}
+	return r
+}
+
+// Render substitutes the parameter values into the template.
+func (r *Renderer) Render(t Template, values map[string]interface{}) (policy.Policy, error) {
+	var errs validator.ValidationErrors
+	resolved := make(map[string]interface{}, len(t.Parameters))
+	given := make(map[string]interface{}, len(values))
+
+	for i, param := range t.Parameters {
+		path := validator.JSONPointer("parameters", i)
+		if !parameterNamePattern.MatchString(param.Name) {
+			errs = append(errs, parameterError(validator.CodeInvalidParameter, path+"/name", "parameter name %q is not a valid identifier", param.Name))
+			continue
+		}
+		if _, seen := resolved[param.Name]; seen {
+			errs = append(errs, parameterError(validator.CodeInvalidParameter, path+"/name", "parameter %q is declared more than once", param.Name))
+			continue
+		}
+
+		if value, ok := values[param.Name]; ok {
+			normalized, err := normalize(param.Type, value)
+			if err != nil {
+				errs = append(errs, parameterError(validator.CodeInvalidParameter, validator.JSONPointer("values", param.Name), "parameter %q: %v", param.Name, err))
+				continue
+			}
+			resolved[param.Name] = normalized
+			given[param.Name] = normalized
+		} else if param.Default != nil {
+			normalized, err := normalize(param.Type, param.Default)
+			if err != nil {
+				errs = append(errs, parameterError(validator.CodeInvalidParameter, path+"/default", "default of parameter %q: %v", param.Name, err))
+				continue
+			}
+			resolved[param.Name] = normalized
+		} else {
+			errs = append(errs, parameterError(validator.CodeRequired, validator.JSONPointer("values", param.Name), "parameter %q is required", param.Name))
+		}
+	}
+	for name := range values {
+		if _, ok := t.parameter(name); !ok {
+			errs = append(errs, parameterError(validator.CodeUnknownParameter, validator.JSONPointer("values", name), "parameter %q is not declared by template %q", name, t.ID))
+		}
+	}
+	if len(errs) > 0 {
+		return policy.Policy{}, errs
+	}
+
+	s := &substitution{template: t, values: resolved, patternChars: globChars + r.options.resourceSeparator}
+	id := s.scalar("/policy/id", t.Policy.ID)
+	name := s.scalar("/policy/name", t.Policy.Name)
+	description := s.scalar("/policy/description", t.Policy.Description)
+	statements := make([]policy.Statement, len(t.Policy.Statements))
+	for i, statement := range t.Policy.Statements {
+		statements[i] = s.statement(r.factory, validator.JSONPointer("policy", "statements", i), statement)
+	}
+	if len(s.errs) > 0 {
+		return policy.Policy{}, s.errs
+	}
+
+	p := r.factory.CreatePolicy(id, name, statements...)
+	p.Description = description
+	p.CreatedAt = r.options.clock.Now()
+	p.Template = &policy.TemplateReference{ID: t.ID, Version: t.Version}
+	if len(given) > 0 {
+		p.Template.Parameters = given
+	}
+	if errs := validator.ValidationErrors(r.options.validator.Validate(p)); errs.HasErrors() {
+		for i := range errs {
+			errs[i].Pointer = "/policy" + errs[i].Pointer
+		}
+		return policy.Policy{}, errs
+	}
+	return p, nil
+}
+
+// Reapply renders t again with the parameter values recorded on p, reporting whether it changed.
+func (r *Renderer) Reapply(t Template, p policy.Policy) (policy.Policy, bool, error) {
+	if p.Template == nil {
+		return policy.Policy{}, false, fmt.Errorf("policy %q was not rendered from a template", p.ID)
+	}
+	if p.Template.ID != t.ID {
+		return policy.Policy{}, false, fmt.Errorf("policy %q was rendered from template %q, not %q", p.ID, p.Template.ID, t.ID)
+	}
+	rendered, err := r.Render(t, p.Template.Parameters)
+	if err != nil {
+		return policy.Policy{}, false, err
+	}
+	rendered.CreatedAt = p.CreatedAt
+	rendered.UpdatedAt = p.UpdatedAt
+
+	before, err := p.Hash()
+	if err != nil {
+		return policy.Policy{}, false, err
+	}
+	after, err := rendered.Hash()
+	if err != nil {
+		return policy.Policy{}, false, err
+	}
+	if before == after {
+		return p, false, nil
+	}
+	rendered.UpdatedAt = r.options.clock.Now()
+	return rendered, true, nil
+}
+
+func parameterError(code validator.ErrorCode, pointer, format string, args ...interface{}) validator.ValidationError {
+	return validator.ValidationError{
+		Field:    "template",
+		Message:  fmt.Sprintf(format, args...),
+		Code:     code,
+		Severity: validator.SeverityError,
+		Pointer:  pointer,
+	}
+}
+
+func normalize(t ParameterType, value interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(value)
+	switch t {
+	case String:
+		if rv.Kind() == reflect.String {
+			return rv.String(), nil
+		}
+		return nil, fmt.Errorf("expected a string, got %T", value)
+	case Number:
+		switch v := value.(type) {
+		case json.Number:
+			if _, err := strconv.ParseFloat(string(v), 64); err != nil {
+				return nil, fmt.Errorf("invalid number %q", v)
+			}
+			return v, nil
+		}
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return json.Number(strconv.FormatInt(rv.Int(), 10)), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return json.Number(strconv.FormatUint(rv.Uint(), 10)), nil
+		case reflect.Float32, reflect.Float64:
+			if f := rv.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+				return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+			}
+		}
+		return nil, fmt.Errorf("expected a number, got %v", value)
+	case List:
+		if rv.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("expected a list of strings, got %T", value)
+		}
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			item := rv.Index(i)
+			if item.Kind() == reflect.Interface {
+				item = item.Elem()
+			}
+			if item.Kind() != reflect.String {
+				return nil, fmt.Errorf("item %d: expected a string", i)
+			}
+			items[i] = item.String()
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("unknown parameter type %q", t)
+}
+
+type substitution struct {
+	template     Template
+	values       map[string]interface{}
+	patternChars string
+	errs         validator.ValidationErrors
+}
+
+func (s *substitution) statement(f factory.IPolicyFactory, path string, t policy.Statement) policy.Statement {
+	actions := s.list(path+"/actions", actionStrings(t.Actions))
+	resources := s.list(path+"/resources", resourceStrings(t.Resources))
+	principals := s.list(path+"/principals", principalStrings(t.Principals))
+	s.checkExpanded(path+"/actions", len(t.Actions), len(actions))
+	s.checkExpanded(path+"/resources", len(t.Resources), len(resources))
+	s.checkExpanded(path+"/principals", len(t.Principals), len(principals))
+	statement := f.CreateStatement(
+		s.scalar(path+"/id", t.ID),
+		policy.Effect(s.scalar(path+"/effect", string(t.Effect))),
+		make([]policy.Action, len(actions)),
+		make([]policy.Resource, len(resources)),
+	)
+	for i, action := range actions {
+		statement.Actions[i] = policy.Action(action)
+	}
+	for i, resource := range resources {
+		statement.Resources[i] = policy.Resource(resource)
+	}
+	for _, principal := range principals {
+		statement.Principals = append(statement.Principals, policy.Principal(principal))
+	}
+	for i, c := range t.Conditions {
+		conditionPath := path + validator.JSONPointer("conditions", i)
+		operator := policy.ConditionOperator(s.scalar(conditionPath+"/operator", string(c.Operator)))
+		statement.Conditions = append(statement.Conditions, policy.Condition{
+			Operator: operator,
+			Key:      policy.ConditionKey(s.scalar(conditionPath+"/key", string(c.Key))),
+			Value:    s.value(conditionPath+"/value", c.Value, conditionPatternChars(operator)),
+		})
+	}
+	return statement
+}
+
+func conditionPatternChars(operator policy.ConditionOperator) string {
+	switch operator {
+	case policy.StringLike, policy.StringNotLike:
+		return globChars
+	case policy.StringMatchesRegex, policy.StringNotMatchesRegex:
+		return regexChars
+	}
+	return ""
+}
+
+func (s *substitution) scalar(path, text string) string {
+	return s.substitute(path, text, "")
+}
+
+func (s *substitution) substitute(path, text, patternChars string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		if match == "$${" {
+			return "${"
+		}
+		name := match[2 : len(match)-1]
+		value, ok := s.lookup(path, name)
+		switch v := value.(type) {
+		case string:
+			s.checkPattern(path, name, v, patternChars)
+			return v
+		case json.Number:
+			return string(v)
+		}
+		if ok {
+			s.errs = append(s.errs, parameterError(validator.CodeInvalidParameter, path,
+				"list parameter %q must be a whole list item or condition value", name))
+		}
+		return match
+	})
+}
+
+func (s *substitution) list(path string, items []string) []string {
+	var result []string
+	for i, item := range items {
+		itemPath := path + validator.JSONPointer(i)
+		if expanded, ok := s.expand(item); ok {
+			name := wholeValuePattern.FindStringSubmatch(item)[1]
+			for _, value := range expanded {
+				s.checkPattern(itemPath, name, value.(string), s.patternChars)
+				result = append(result, value.(string))
+			}
+			continue
+		}
+		result = append(result, s.substitute(itemPath, item, s.patternChars))
+	}
+	return result
+}
+
+func (s *substitution) checkPattern(path, name, value, patternChars string) {
+	if patternChars == "" {
+		return
+	}
+	if param, _ := s.template.parameter(name); param.AllowPatterns {
+		return
+	}
+	if strings.ContainsAny(value, patternChars) {
+		s.errs = append(s.errs, parameterError(validator.CodeInvalidParameter, path,
+			"value %q of parameter %q contains wildcard or separator characters, which requires allow_patterns", value, name))
+	}
+}
+
+func (s *substitution) checkExpanded(path string, before, after int) {
+	if before > 0 && after == 0 {
+		s.errs = append(s.errs, parameterError(validator.CodeInvalidParameter, path,
+			"list parameters expand to an empty list"))
+	}
+}
+
+func (s *substitution) value(path string, value interface{}, patternChars string) interface{} {
+	switch v := value.(type) {
+	case string:
+		if m := wholeValuePattern.FindStringSubmatch(v); m != nil {
+			value, ok := s.lookup(path, m[1])
+			if !ok {
+				return v
+			}
+			switch typed := value.(type) {
+			case []interface{}:
+				for i, item := range typed {
+					s.checkPattern(path+validator.JSONPointer(i), m[1], item.(string), patternChars)
+				}
+				return append([]interface{}{}, typed...)
+			case string:
+				s.checkPattern(path, m[1], typed, patternChars)
+			}
+			return value
+		}
+		return s.substitute(path, v, patternChars)
+	case []interface{}:
+		var items []interface{}
+		for i, item := range v {
+			if text, ok := item.(string); ok {
+				if expanded, ok := s.expand(text); ok {
+					name := wholeValuePattern.FindStringSubmatch(text)[1]
+					for _, value := range expanded {
+						s.checkPattern(path+validator.JSONPointer(i), name, value.(string), patternChars)
+					}
+					items = append(items, expanded...)
+					continue
+				}
+			}
+			items = append(items, s.value(path+validator.JSONPointer(i), item, patternChars))
+		}
+		return items
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = s.value(path+validator.JSONPointer(key), item, patternChars)
+		}
+		return m
+	}
+	return value
+}
+
+func (s *substitution) expand(text string) ([]interface{}, bool) {
+	m := wholeValuePattern.FindStringSubmatch(text)
+	if m == nil {
+		return nil, false
+	}
+	list, ok := s.values[m[1]].([]interface{})
+	return list, ok
+}
+
+func (s *substitution) lookup(path, name string) (interface{}, bool) {
+	value, ok := s.values[name]
+	if !ok {
+		s.errs = append(s.errs, parameterError(validator.CodeUnknownParameter, path,
+			"placeholder %q does not name a parameter of template %q", name, s.template.ID))
+	}
+	return value, ok
+}
+
+func principalStrings(values []policy.Principal) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = string(v)
+	}
+	return result
+}
+
+func actionStrings(values []policy.Action) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = string(v)
+	}
+	return result
+}
+
+func resourceStrings(values []policy.Resource) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = string(v)
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+package template
+
+import (
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+type ParameterType string
+
+const (
+	String ParameterType = "string"
+	List   ParameterType = "list"
+	Number ParameterType = "number"
+)
+
+// Parameter declares a template parameter, required when it has no default.
+type Parameter struct {
+	Name          string        `json:"name" yaml:"name"`
+	Type          ParameterType `json:"type" yaml:"type"`
+	Description   string        `json:"description,omitempty" yaml:"description,omitempty"`
+	Default       interface{}   `json:"default,omitempty" yaml:"default,omitempty"`
+	AllowPatterns bool          `json:"allow_patterns,omitempty" yaml:"allow_patterns,omitempty"`
+}
+
+// Template describes a family of policies with "${name}" placeholders.
+type Template struct {
+	ID string `json:"id" yaml:"id"`
+	// Version identifies the revision of the template.
+	Version     string         `json:"version,omitempty" yaml:"version,omitempty"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Parameters  []Parameter    `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Policy      PolicyTemplate `json:"policy" yaml:"policy"`
+}
+
+// PolicyTemplate holds the policy fields a template renders.
+type PolicyTemplate struct {
+	ID          string             `json:"id" yaml:"id"`
+	Name        string             `json:"name" yaml:"name"`
+	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Statements  []policy.Statement `json:"statements" yaml:"statements"`
+}
+
+func (t Template) parameter(name string) (Parameter, bool) {
+	for _, p := range t.Parameters {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Parameter{}, false
+}
@@ -8,6 +8,8 @@ type Action string
 
 type Resource string
 
+type Principal string
+
 type Effect string
 
 const (
@@ -24,6 +26,8 @@ const (
 	StringNotEqualsIgnoreCase ConditionOperator = "StringNotEqualsIgnoreCase"
 	StringLike                ConditionOperator = "StringLike"
 	StringNotLike             ConditionOperator = "StringNotLike"
+	StringMatchesRegex        ConditionOperator = "StringMatchesRegex"
+	StringNotMatchesRegex     ConditionOperator = "StringNotMatchesRegex"
 
 	NumericEquals            ConditionOperator = "NumericEquals"
 	NumericNotEquals         ConditionOperator = "NumericNotEquals"
@@ -40,6 +44,27 @@ const (
 	DateGreaterThanEquals ConditionOperator = "DateGreaterThanEquals"
 
 	Bool ConditionOperator = "Bool"
+
+	VersionEquals            ConditionOperator = "VersionEquals"
+	VersionNotEquals         ConditionOperator = "VersionNotEquals"
+	VersionLessThan          ConditionOperator = "VersionLessThan"
+	VersionLessThanEquals    ConditionOperator = "VersionLessThanEquals"
+	VersionGreaterThan       ConditionOperator = "VersionGreaterThan"
+	VersionGreaterThanEquals ConditionOperator = "VersionGreaterThanEquals"
+
+	DurationEquals            ConditionOperator = "DurationEquals"
+	DurationNotEquals         ConditionOperator = "DurationNotEquals"
+	DurationLessThan          ConditionOperator = "DurationLessThan"
+	DurationLessThanEquals    ConditionOperator = "DurationLessThanEquals"
+	DurationGreaterThan       ConditionOperator = "DurationGreaterThan"
+	DurationGreaterThanEquals ConditionOperator = "DurationGreaterThanEquals"
+
+	ScheduleTimeOfDay ConditionOperator = "ScheduleTimeOfDay"
+	ScheduleDayOfWeek ConditionOperator = "ScheduleDayOfWeek"
+	ScheduleCron      ConditionOperator = "ScheduleCron"
+
+	ListContains    ConditionOperator = "ListContains"
+	ListNotContains ConditionOperator = "ListNotContains"
 )
 
 type ConditionKey string
@@ -47,27 +72,38 @@ type ConditionKey string
 type ConditionValue interface{}
 
 type Condition struct {
-	Operator ConditionOperator `json:"operator"`
-	Key      ConditionKey      `json:"key"`
-	Value    ConditionValue    `json:"value"`
+	Operator ConditionOperator `json:"operator" yaml:"operator"`
+	Key      ConditionKey      `json:"key" yaml:"key"`
+	Value    ConditionValue    `json:"value" yaml:"value"`
 }
 
 type Statement struct {
-	ID         string      `json:"id,omitempty"`
-	Effect     Effect      `json:"effect"`
-	Actions    []Action    `json:"actions"`
-	Resources  []Resource  `json:"resources"`
-	Conditions []Condition `json:"conditions,omitempty"`
+	ID     string `json:"id,omitempty" yaml:"id,omitempty"`
+	Effect Effect `json:"effect" yaml:"effect"`
+	// Principals restricts the statement to matching principals, all when empty.
+	Principals []Principal `json:"principals,omitempty" yaml:"principals,omitempty"`
+	Actions    []Action    `json:"actions" yaml:"actions"`
+	Resources  []Resource  `json:"resources" yaml:"resources"`
+	Conditions []Condition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+}
+
+// TemplateReference links a rendered policy to its template and parameter values.
+type TemplateReference struct {
+	ID         string                 `json:"id" yaml:"id"`
+	Version    string                 `json:"version,omitempty" yaml:"version,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty" yaml:"parameters,omitempty"`
 }
 
 type Policy struct {
-	Version     string      `json:"version"`
-	ID          string      `json:"id"`
-	Name        string      `json:"name"`
-	Description string      `json:"description,omitempty"`
-	Statements  []Statement `json:"statements"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at,omitempty"`
+	Version     string      `json:"version" yaml:"version"`
+	ID          string      `json:"id" yaml:"id"`
+	Name        string      `json:"name" yaml:"name"`
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Statements  []Statement `json:"statements" yaml:"statements"`
+	CreatedAt   time.Time   `json:"created_at" yaml:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+	// Template is set on policies rendered from a template.
+	Template *TemplateReference `json:"template,omitempty" yaml:"template,omitempty"`
 }
 
 const (
@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/catalog"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/coverage"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+)
+
+// CatalogValidator checks statements against a catalog of known names.
+type CatalogValidator struct {
+	catalog        *catalog.Catalog
+	patternMatcher condition.PatternMatcher
+	syntax         policy.ResourceNameSyntax
+}
+
+func NewCatalogValidator(cat *catalog.Catalog) *CatalogValidator {
+	return NewCatalogValidatorWithMatcher(cat, condition.NewRegexPatternMatcher())
+}
+
+func NewCatalogValidatorWithMatcher(cat *catalog.Catalog, matcher condition.PatternMatcher) *CatalogValidator {
+	return &CatalogValidator{
+		catalog:        cat,
+		patternMatcher: matcher,
+		syntax:         policy.DefaultResourceNameSyntax,
+	}
+}
+
+func (v *CatalogValidator) ValidateStatement(statement policy.Statement, index int) []ValidationError {
+	var errors []ValidationError
+	fieldPrefix := fmt.Sprintf("Statements[%d].", index)
+
+	var matched []policy.Action
+	for j, action := range statement.Actions {
+		actionMatches := v.matchActions(action)
+		if len(actionMatches) == 0 {
+			code, message := CodeUnknownAction, fmt.Sprintf("Unknown action %q", action)
+			if coverage.HasWildcard(string(action)) {
+				code, message = CodeNoMatchingAction, fmt.Sprintf("Action pattern %q does not match any known action", action)
+			}
+			errors = append(errors, ValidationError{
+				Field:    fmt.Sprintf("%sActions[%d]", fieldPrefix, j),
+				Message:  message,
+				Code:     code,
+				Severity: SeverityError,
+				Pointer:  JSONPointer("statements", index, "actions", j),
+			})
+		}
+		matched = append(matched, actionMatches...)
+	}
+
+	for j, resource := range statement.Resources {
+		resourceType := policy.ParseResourceName(resource, v.syntax).Type()
+		if resourceType == "" || coverage.HasWildcard(resourceType) {
+			continue
+		}
+		if _, ok := v.catalog.ResourceType(resourceType); !ok {
+			errors = append(errors, ValidationError{
+				Field:    fmt.Sprintf("%sResources[%d]", fieldPrefix, j),
+				Message:  fmt.Sprintf("Unknown resource type %q", resourceType),
+				Code:     CodeUnknownResourceType,
+				Severity: SeverityError,
+				Pointer:  JSONPointer("statements", index, "resources", j),
+			})
+			continue
+		}
+		if len(matched) > 0 && !v.anySupports(matched, func(action policy.Action) bool {
+			return v.catalog.SupportsResourceType(action, resourceType)
+		}) {
+			errors = append(errors, ValidationError{
+				Field:    fmt.Sprintf("%sResources[%d]", fieldPrefix, j),
+				Message:  fmt.Sprintf("Resource type %q is not applicable to the statement's actions", resourceType),
+				Code:     CodeResourceTypeNotApplicable,
+				Severity: SeverityError,
+				Pointer:  JSONPointer("statements", index, "resources", j),
+			})
+		}
+	}
+
+	if len(matched) == 0 {
+		return errors
+	}
+	for j, cond := range statement.Conditions {
+		if cond.Key == "" {
+			continue
+		}
+		if !v.anySupports(matched, func(action policy.Action) bool {
+			return v.catalog.SupportsConditionKey(action, cond.Key)
+		}) {
+			errors = append(errors, ValidationError{
+				Field:    fmt.Sprintf("%sConditions[%d].Key", fieldPrefix, j),
+				Message:  fmt.Sprintf("Condition key %q is not applicable to the statement's actions", cond.Key),
+				Code:     CodeConditionKeyNotApplicable,
+				Severity: SeverityError,
+				Pointer:  JSONPointer("statements", index, "conditions", j, "key"),
+			})
+		}
+	}
+
+	return errors
+}
+
+func (v *CatalogValidator) matchActions(pattern policy.Action) []policy.Action {
+	if _, ok := v.catalog.Action(pattern); ok {
+		return []policy.Action{pattern}
+	}
+	var matches []policy.Action
+	for _, action := range v.catalog.Actions() {
+		if v.patternMatcher.MatchesPattern(string(action), string(pattern)) {
+			matches = append(matches, action)
+		}
+	}
+	return matches
+}
+
+func (v *CatalogValidator) anySupports(actions []policy.Action, supports func(policy.Action) bool) bool {
+	for _, action := range actions {
+		if supports(action) {
+			return true
+		}
+	}
+	return false
+}
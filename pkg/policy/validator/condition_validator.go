@@ -2,40 +2,199 @@ package validator
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/schema"
 )
 
-type ConditionValidator struct{}
+type ConditionValidator struct {
+	operators     *condition.OperatorRegistry
+	contextSchema *schema.ContextSchema
+}
 
 func NewConditionValidator() *ConditionValidator {
-	return &ConditionValidator{}
+	return NewConditionValidatorWithOperators(condition.NewOperatorRegistry())
+}
+
+func NewConditionValidatorWithOperators(operators *condition.OperatorRegistry) *ConditionValidator {
+	return &ConditionValidator{
+		operators: operators,
+	}
 }
 
-func (v *ConditionValidator) ValidateCondition(condition policy.Condition, stmIndex, condIndex int) []ValidationError {
+// NewConditionValidatorWithSchema also checks operators against the declared key types.
+func NewConditionValidatorWithSchema(operators *condition.OperatorRegistry, contextSchema *schema.ContextSchema) *ConditionValidator {
+	v := NewConditionValidatorWithOperators(operators)
+	v.contextSchema = contextSchema
+	return v
+}
+
+func (v *ConditionValidator) ValidateCondition(cond policy.Condition, stmIndex, condIndex int) []ValidationError {
 	var errors []ValidationError
 	fieldPrefix := fmt.Sprintf("Statements[%d].Conditions[%d].", stmIndex, condIndex)
+	pointerPrefix := JSONPointer("statements", stmIndex, "conditions", condIndex) + "/"
 
-	if condition.Operator == "" {
+	known := v.operators.IsKnown(cond.Operator)
+	if cond.Operator == "" {
 		errors = append(errors, ValidationError{
-			Field:   fieldPrefix + "Operator",
-			Message: "Condition operator is required",
+			Field:    fieldPrefix + "Operator",
+			Message:  "Condition operator is required",
+			Code:     CodeRequired,
+			Severity: SeverityError,
+			Pointer:  pointerPrefix + "operator",
+		})
+	} else if !known {
+		errors = append(errors, ValidationError{
+			Field:    fieldPrefix + "Operator",
+			Message:  fmt.Sprintf("Unknown condition operator %q", cond.Operator),
+			Code:     CodeUnknownOperator,
+			Severity: SeverityError,
+			Pointer:  pointerPrefix + "operator",
 		})
 	}
 
-	if condition.Key == "" {
+	if cond.Key == "" && !cond.Operator.IsSchedule() {
 		errors = append(errors, ValidationError{
-			Field:   fieldPrefix + "Key",
-			Message: "Condition key is required",
+			Field:    fieldPrefix + "Key",
+			Message:  "Condition key is required",
+			Code:     CodeRequired,
+			Severity: SeverityError,
+			Pointer:  pointerPrefix + "key",
 		})
 	}
 
-	if condition.Value == nil {
+	if v.contextSchema != nil && cond.Key != "" && known {
+		if err, ok := v.validateKeyType(cond); !ok {
+			err.Field = fieldPrefix + "Key"
+			err.Pointer = pointerPrefix + "key"
+			errors = append(errors, err)
+		}
+	}
+
+	if cond.Value == nil {
 		errors = append(errors, ValidationError{
-			Field:   fieldPrefix + "Value",
-			Message: "Condition value cannot be nil",
+			Field:    fieldPrefix + "Value",
+			Message:  "Condition value cannot be nil",
+			Code:     CodeRequired,
+			Severity: SeverityError,
+			Pointer:  pointerPrefix + "value",
 		})
+	} else if known {
+		if err := v.validateValue(cond); err != nil {
+			errors = append(errors, ValidationError{
+				Field:    fieldPrefix + "Value",
+				Message:  err.Error(),
+				Code:     CodeInvalidConditionValue,
+				Severity: SeverityError,
+				Pointer:  pointerPrefix + "value",
+			})
+		}
 	}
 
 	return errors
 }
+
+func (v *ConditionValidator) validateKeyType(cond policy.Condition) (ValidationError, bool) {
+	definition, declared := v.contextSchema.Lookup(cond.Key)
+	if !declared {
+		if !v.contextSchema.Strict {
+			return ValidationError{}, true
+		}
+		return ValidationError{
+			Message:  fmt.Sprintf("Condition key %q is not declared in the context schema", cond.Key),
+			Code:     CodeUndeclaredConditionKey,
+			Severity: SeverityError,
+		}, false
+	}
+
+	family, _ := cond.Operator.Family()
+	if custom, ok := v.operators.Lookup(cond.Operator); ok {
+		family = custom.Family
+	}
+	if !definition.SupportsFamily(family) {
+		return ValidationError{
+			Message:  fmt.Sprintf("Operator %s cannot be applied to %s key %q", cond.Operator, definition.Type, cond.Key),
+			Code:     CodeConditionKeyTypeMismatch,
+			Severity: SeverityError,
+		}, false
+	}
+	return ValidationError{}, true
+}
+
+func (v *ConditionValidator) validateValue(cond policy.Condition) error {
+	if custom, ok := v.operators.Lookup(cond.Operator); ok {
+		if custom.ValidateValue == nil {
+			return nil
+		}
+		if err := custom.ValidateValue(cond.Value); err != nil {
+			return fmt.Errorf("Operator %s rejected value %v: %v", cond.Operator, cond.Value, err)
+		}
+		return nil
+	}
+
+	family, _ := cond.Operator.Family()
+	switch family {
+	case policy.StringFamily:
+		s, ok := cond.Value.(string)
+		if !ok {
+			return valueTypeError(cond, "a string")
+		}
+		if cond.Operator == policy.StringMatchesRegex || cond.Operator == policy.StringNotMatchesRegex {
+			if _, err := condition.CompileRegex(s); err != nil {
+				return fmt.Errorf("Operator %s requires a valid RE2 expression: %v", cond.Operator, err)
+			}
+		}
+	case policy.NumericFamily:
+		if _, err := condition.ParseNumber(cond.Value); err != nil {
+			return valueFormatError(cond, "a number", err)
+		}
+	case policy.DateFamily:
+		if _, err := condition.ParseTime(cond.Value, time.Now()); err != nil {
+			return valueFormatError(cond, "an RFC3339 date, a Unix epoch or a relative expression such as now-15m", err)
+		}
+	case policy.BoolFamily:
+		if _, ok := cond.Value.(bool); !ok {
+			return valueTypeError(cond, "a boolean")
+		}
+	case policy.ListFamily:
+		if _, ok := cond.Value.(string); !ok {
+			return valueTypeError(cond, "a string")
+		}
+	case policy.VersionFamily:
+		s, ok := cond.Value.(string)
+		if !ok {
+			return valueTypeError(cond, "a version string")
+		}
+		if _, err := condition.ParseVersion(s); err != nil {
+			return fmt.Errorf("Operator %s requires a semantic version: %v", cond.Operator, err)
+		}
+	case policy.DurationFamily:
+		if _, err := condition.ParseDuration(cond.Value); err != nil {
+			return fmt.Errorf("Operator %s requires a Go or ISO 8601 duration: %v", cond.Operator, err)
+		}
+	case policy.ScheduleFamily:
+		var err error
+		switch cond.Operator {
+		case policy.ScheduleTimeOfDay:
+			_, err = condition.ParseTimeOfDayRange(cond.Value)
+		case policy.ScheduleDayOfWeek:
+			_, err = condition.ParseWeekdaySet(cond.Value)
+		case policy.ScheduleCron:
+			_, err = condition.ParseCronSchedule(cond.Value)
+		}
+		if err != nil {
+			return fmt.Errorf("Operator %s requires a valid schedule: %v", cond.Operator, err)
+		}
+	}
+	return nil
+}
+
+func valueTypeError(cond policy.Condition, expected string) error {
+	return fmt.Errorf("Operator %s requires %s value, got %T %v", cond.Operator, expected, cond.Value, cond.Value)
+}
+
+func valueFormatError(cond policy.Condition, expected string, err error) error {
+	return fmt.Errorf("Operator %s requires %s, got %T %v: %v", cond.Operator, expected, cond.Value, cond.Value, err)
+}
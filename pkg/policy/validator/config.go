@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+// ValidatorConfig holds the tunable limits of the validator chain; zero disables a check.
+type ValidatorConfig struct {
+	RequireID                 bool     `json:"require_id"`
+	RequireName               bool     `json:"require_name"`
+	MaxStatements             int      `json:"max_statements"`
+	MaxActionsPerStatement    int      `json:"max_actions_per_statement"`
+	MaxResourcesPerStatement  int      `json:"max_resources_per_statement"`
+	MaxConditionsPerStatement int      `json:"max_conditions_per_statement"`
+	SupportedVersions         []string `json:"supported_versions,omitempty"`
+	FailFast                  bool     `json:"fail_fast"`
+}
+
+func DefaultValidatorConfig() ValidatorConfig {
+	return ValidatorConfig{
+		RequireID:                 true,
+		RequireName:               true,
+		MaxStatements:             100,
+		MaxActionsPerStatement:    50,
+		MaxResourcesPerStatement:  100,
+		MaxConditionsPerStatement: 20,
+		SupportedVersions:         []string{policy.PolicyVersion},
+	}
+}
+
+// LoadValidatorConfig reads a JSON config over the default values.
+func LoadValidatorConfig(data []byte) (ValidatorConfig, error) {
+	config := DefaultValidatorConfig()
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return ValidatorConfig{}, fmt.Errorf("invalid validator config: %v", err)
+	}
+	if decoder.More() {
+		return ValidatorConfig{}, fmt.Errorf("invalid validator config: unexpected data after the config object")
+	}
+	if err := config.Validate(); err != nil {
+		return ValidatorConfig{}, err
+	}
+	return config, nil
+}
+
+func (c ValidatorConfig) Validate() error {
+	limits := map[string]int{
+		"max_statements":               c.MaxStatements,
+		"max_actions_per_statement":    c.MaxActionsPerStatement,
+		"max_resources_per_statement":  c.MaxResourcesPerStatement,
+		"max_conditions_per_statement": c.MaxConditionsPerStatement,
+	}
+	for name, limit := range limits {
+		if limit < 0 {
+			return fmt.Errorf("invalid validator config: %s cannot be negative", name)
+		}
+	}
+	return nil
+}
@@ -2,36 +2,125 @@ package validator
 
 import (
 	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/catalog"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/schema"
 )
 
 type DefaultValidator struct {
 	policyFieldsValidator IPolicyFieldsValidator
 	policyValidator       *PolicyValidator
+	statementValidators   []IStatementValidator
+	rules                 []IPolicyValidator
+	failFast              bool
 }
 
-func NewDefaultValidator() *DefaultValidator {
-	statementValidator := NewStatementValidator()
-	conditionValidator := NewConditionValidator()
+// NewValidator builds the validator chain from options over DefaultValidatorConfig.
+func NewValidator(opts ...Option) *DefaultValidator {
+	o := validatorOptions{
+		config:    DefaultValidatorConfig(),
+		operators: condition.NewOperatorRegistry(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	policyFieldsValidator := &PolicyFieldsValidator{
+		RequireID:         o.config.RequireID,
+		RequireName:       o.config.RequireName,
+		MaxStatements:     o.config.MaxStatements,
+		SupportedVersions: o.config.SupportedVersions,
+	}
+	statementValidator := &StatementValidator{
+		MaxActionsPerStm:    o.config.MaxActionsPerStatement,
+		MaxResourcesPerStm:  o.config.MaxResourcesPerStatement,
+		MaxConditionsPerStm: o.config.MaxConditionsPerStatement,
+	}
+	conditionValidator := NewConditionValidatorWithSchema(o.operators, o.contextSchema)
+
+	var statementValidators []IStatementValidator
+	if o.catalog != nil {
+		statementValidators = append(statementValidators, NewCatalogValidator(o.catalog))
+	}
+	statementValidators = append(statementValidators, o.statementValidators...)
 
 	return &DefaultValidator{
-		policyFieldsValidator: NewPolicyFieldsValidator(),
+		policyFieldsValidator: policyFieldsValidator,
 		policyValidator:       NewPolicyValidator(statementValidator, conditionValidator),
+		statementValidators:   statementValidators,
+		rules:                 o.rules,
+		failFast:              o.config.FailFast,
 	}
 }
 
+func NewDefaultValidator() *DefaultValidator {
+	return NewValidator()
+}
+
+func NewDefaultValidatorWithOperators(operators *condition.OperatorRegistry) *DefaultValidator {
+	return NewValidator(WithOperators(operators))
+}
+
+// NewDefaultValidatorWithSchema additionally checks operators against the declared key types.
+func NewDefaultValidatorWithSchema(operators *condition.OperatorRegistry, contextSchema *schema.ContextSchema) *DefaultValidator {
+	return NewValidator(WithOperators(operators), WithContextSchema(contextSchema))
+}
+
+// NewDefaultValidatorWithCatalog additionally rejects names not declared in the catalog.
+func NewDefaultValidatorWithCatalog(operators *condition.OperatorRegistry, cat *catalog.Catalog) *DefaultValidator {
+	return NewValidator(WithOperators(operators), WithCatalog(cat))
+}
+
 func (v *DefaultValidator) Validate(policy policy.Policy) []ValidationError {
-	errors := v.policyFieldsValidator.ValidateFields(policy)
+	var errors []ValidationError
+
+	if v.collect(&errors, v.policyFieldsValidator.ValidateFields(policy)) {
+		return errors
+	}
+
+	for i, statement := range policy.Statements {
+		if v.collect(&errors, v.ValidateStatement(statement, i)) {
+			return errors
+		}
+	}
 
-	evalErrors := v.policyValidator.ValidatePolicy(policy)
-	errors = append(errors, evalErrors...)
+	for _, rule := range v.rules {
+		if v.collect(&errors, rule.Validate(policy)) {
+			return errors
+		}
+	}
 
 	return errors
 }
 
 func (v *DefaultValidator) ValidateStatement(statement policy.Statement, index int) []ValidationError {
-	return v.policyValidator.ValidateStatement(statement, index)
+	var errors []ValidationError
+
+	if v.collect(&errors, v.policyValidator.ValidateStatement(statement, index)) {
+		return errors
+	}
+
+	for _, statementValidator := range v.statementValidators {
+		if v.collect(&errors, statementValidator.ValidateStatement(statement, index)) {
+			return errors
+		}
+	}
+
+	return errors
 }
 
 func (v *DefaultValidator) ValidateCondition(condition policy.Condition, stmIndex, condIndex int) []ValidationError {
-	return v.policyValidator.ValidateCondition(condition, stmIndex, condIndex)
+	var errors []ValidationError
+	v.collect(&errors, v.policyValidator.ValidateCondition(condition, stmIndex, condIndex))
+	return errors
+}
+
+func (v *DefaultValidator) collect(errors *[]ValidationError, found []ValidationError) bool {
+	for _, err := range found {
+		*errors = append(*errors, err)
+		if v.failFast && (err.Severity == SeverityError || err.Severity == "") {
+			return true
+		}
+	}
+	return false
 }
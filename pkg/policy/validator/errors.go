@@ -1,6 +1,115 @@
 package validator
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+// ErrorCode is a stable, machine-readable identifier for a validation failure.
+type ErrorCode string
+
+const (
+	CodeRequired              ErrorCode = "required"
+	CodeLimitExceeded         ErrorCode = "limit_exceeded"
+	CodeUnsupportedVersion    ErrorCode = "unsupported_version"
+	CodeInvalidEffect         ErrorCode = "invalid_effect"
+	CodeUnknownOperator       ErrorCode = "unknown_operator"
+	CodeInvalidConditionValue ErrorCode = "invalid_condition_value"
+
+	CodeUnknownAction             ErrorCode = "unknown_action"
+	CodeNoMatchingAction          ErrorCode = "no_matching_action"
+	CodeUnknownResourceType       ErrorCode = "unknown_resource_type"
+	CodeResourceTypeNotApplicable ErrorCode = "resource_type_not_applicable"
+	CodeConditionKeyNotApplicable ErrorCode = "condition_key_not_applicable"
+
+	CodeUndeclaredConditionKey   ErrorCode = "undeclared_condition_key"
+	CodeConditionKeyTypeMismatch ErrorCode = "condition_key_type_mismatch"
+
+	CodeDuplicatePolicyID     ErrorCode = "duplicate_policy_id"
+	CodeDuplicateStatementID  ErrorCode = "duplicate_statement_id"
+	CodeConflictingStatements ErrorCode = "conflicting_statements"
+
+	CodeUnknownParameter ErrorCode = "unknown_parameter"
+	CodeInvalidParameter ErrorCode = "invalid_parameter"
+)
+
 type ValidationError struct {
-	Field   string
-	Message string
+	Field    string    `json:"field"`
+	Message  string    `json:"message"`
+	Code     ErrorCode `json:"code"`
+	Severity Severity  `json:"severity"`
+	// Pointer is an RFC 6901 JSON pointer to the offending value.
+	Pointer string `json:"pointer"`
+	// PolicyID identifies the policy when a set is validated together.
+	PolicyID string `json:"policy_id,omitempty"`
+}
+
+func (e ValidationError) Error() string {
+	message := e.Message
+	if e.Field != "" {
+		message = fmt.Sprintf("%s: %s", e.Field, e.Message)
+	}
+	if e.PolicyID != "" {
+		message = fmt.Sprintf("policy %s: %s", e.PolicyID, message)
+	}
+	return message
+}
+
+// ValidationErrors aggregates the errors reported for a policy.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	switch len(e) {
+	case 0:
+		return "no validation errors"
+	case 1:
+		return e[0].Error()
+	}
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(e), strings.Join(messages, "; "))
+}
+
+// Err returns e as an error, or nil when there are no errors.
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// HasErrors reports whether any entry has error severity.
+func (e ValidationErrors) HasErrors() bool {
+	for _, err := range e {
+		if err.Severity == SeverityError || err.Severity == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// StreamValidation adapts v for policy.WithStreamValidation, failing only on errors.
+func StreamValidation(v IPolicyValidator) func(policy.Policy) error {
+	return func(p policy.Policy) error {
+		errs := ValidationErrors(v.Validate(p))
+		if !errs.HasErrors() {
+			return nil
+		}
+		return errs
+	}
+}
+
+// JSONPointer builds an escaped RFC 6901 pointer from reference tokens.
+func JSONPointer(tokens ...interface{}) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		escaped := strings.ReplaceAll(fmt.Sprint(token), "~", "~0")
+		b.WriteString(strings.ReplaceAll(escaped, "/", "~1"))
+	}
+	return b.String()
 }
@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/catalog"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/schema"
+)
+
+type validatorOptions struct {
+	config              ValidatorConfig
+	operators           *condition.OperatorRegistry
+	catalog             *catalog.Catalog
+	contextSchema       *schema.ContextSchema
+	statementValidators []IStatementValidator
+	rules               []IPolicyValidator
+}
+
+type Option func(*validatorOptions)
+
+// RuleFunc adapts a function to IPolicyValidator.
+type RuleFunc func(policy policy.Policy) []ValidationError
+
+func (f RuleFunc) Validate(policy policy.Policy) []ValidationError {
+	return f(policy)
+}
+
+func WithConfig(config ValidatorConfig) Option {
+	return func(o *validatorOptions) {
+		o.config = config
+	}
+}
+
+func WithMaxStatements(max int) Option {
+	return func(o *validatorOptions) {
+		o.config.MaxStatements = max
+	}
+}
+
+func WithStatementLimits(maxActions, maxResources, maxConditions int) Option {
+	return func(o *validatorOptions) {
+		o.config.MaxActionsPerStatement = maxActions
+		o.config.MaxResourcesPerStatement = maxResources
+		o.config.MaxConditionsPerStatement = maxConditions
+	}
+}
+
+func WithSupportedVersions(versions ...string) Option {
+	return func(o *validatorOptions) {
+		o.config.SupportedVersions = versions
+	}
+}
+
+// WithFailFast makes validation stop and return at the first error.
+func WithFailFast() Option {
+	return func(o *validatorOptions) {
+		o.config.FailFast = true
+	}
+}
+
+func WithOperators(operators *condition.OperatorRegistry) Option {
+	return func(o *validatorOptions) {
+		o.operators = operators
+	}
+}
+
+func WithCatalog(cat *catalog.Catalog) Option {
+	return func(o *validatorOptions) {
+		o.catalog = cat
+	}
+}
+
+func WithContextSchema(contextSchema *schema.ContextSchema) Option {
+	return func(o *validatorOptions) {
+		o.contextSchema = contextSchema
+	}
+}
+
+// WithStatementRule adds a validator run on every statement after the built-in checks.
+func WithStatementRule(rule IStatementValidator) Option {
+	return func(o *validatorOptions) {
+		o.statementValidators = append(o.statementValidators, rule)
+	}
+}
+
+// WithRule adds a policy-level validator run after all statements.
+func WithRule(rule IPolicyValidator) Option {
+	return func(o *validatorOptions) {
+		o.rules = append(o.rules, rule)
+	}
+}
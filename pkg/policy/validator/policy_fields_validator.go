@@ -10,13 +10,16 @@ type PolicyFieldsValidator struct {
 	RequireID     bool
 	RequireName   bool
 	MaxStatements int
+	// SupportedVersions lists the accepted policy versions, any when empty.
+	SupportedVersions []string
 }
 
 func NewPolicyFieldsValidator() *PolicyFieldsValidator {
 	return &PolicyFieldsValidator{
-		RequireID:     true,
-		RequireName:   true,
-		MaxStatements: 100,
+		RequireID:         true,
+		RequireName:       true,
+		MaxStatements:     100,
+		SupportedVersions: []string{policy.PolicyVersion},
 	}
 }
 
@@ -25,45 +28,83 @@ func (v *PolicyFieldsValidator) ValidateFields(policy policy.Policy) []Validatio
 
 	if v.RequireID && policy.ID == "" {
 		errors = append(errors, ValidationError{
-			Field:   "ID",
-			Message: "Policy ID is required",
+			Field:    "ID",
+			Message:  "Policy ID is required",
+			Code:     CodeRequired,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("id"),
 		})
 	}
 
 	if v.RequireName && policy.Name == "" {
 		errors = append(errors, ValidationError{
-			Field:   "Name",
-			Message: "Policy Name is required",
+			Field:    "Name",
+			Message:  "Policy Name is required",
+			Code:     CodeRequired,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("name"),
 		})
 	}
 
 	if policy.Version == "" {
 		errors = append(errors, ValidationError{
-			Field:   "Version",
-			Message: "Policy Version is required",
+			Field:    "Version",
+			Message:  "Policy Version is required",
+			Code:     CodeRequired,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("version"),
+		})
+	} else if !v.isSupportedVersion(policy.Version) {
+		errors = append(errors, ValidationError{
+			Field:    "Version",
+			Message:  fmt.Sprintf("Unsupported policy version %q", policy.Version),
+			Code:     CodeUnsupportedVersion,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("version"),
 		})
 	}
 
 	if policy.CreatedAt.IsZero() {
 		errors = append(errors, ValidationError{
-			Field:   "CreatedAt",
-			Message: "Creation date is required",
+			Field:    "CreatedAt",
+			Message:  "Creation date is required",
+			Code:     CodeRequired,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("created_at"),
 		})
 	}
 
 	if len(policy.Statements) == 0 {
 		errors = append(errors, ValidationError{
-			Field:   "Statements",
-			Message: "Policy must have at least one statement",
+			Field:    "Statements",
+			Message:  "Policy must have at least one statement",
+			Code:     CodeRequired,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("statements"),
 		})
 	}
 
 	if v.MaxStatements > 0 && len(policy.Statements) > v.MaxStatements {
 		errors = append(errors, ValidationError{
-			Field:   "Statements",
-			Message: fmt.Sprintf("Policy exceeds maximum number of statements (%d)", v.MaxStatements),
+			Field:    "Statements",
+			Message:  fmt.Sprintf("Policy exceeds maximum number of statements (%d)", v.MaxStatements),
+			Code:     CodeLimitExceeded,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("statements"),
 		})
 	}
 
 	return errors
 }
+
+func (v *PolicyFieldsValidator) isSupportedVersion(version string) bool {
+	if len(v.SupportedVersions) == 0 {
+		return true
+	}
+	for _, supported := range v.SupportedVersions {
+		if supported == version {
+			return true
+		}
+	}
+	return false
+}
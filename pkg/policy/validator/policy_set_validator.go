@@ -0,0 +1,165 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/coverage"
+)
+
+// PolicySetValidator validates a set of policies and the conflicts between them.
+type PolicySetValidator struct {
+	MaxPolicies        int
+	MaxTotalStatements int
+	DetectConflicts    bool
+
+	policyValidator IPolicyValidator
+	coverage        *coverage.Coverage
+}
+
+func NewPolicySetValidator(policyValidator IPolicyValidator) *PolicySetValidator {
+	return NewPolicySetValidatorWithCoverage(policyValidator, coverage.NewDefaultCoverage())
+}
+
+// NewPolicySetValidatorWithCoverage detects conflicts with the given coverage.
+func NewPolicySetValidatorWithCoverage(policyValidator IPolicyValidator, statementCoverage *coverage.Coverage) *PolicySetValidator {
+	return &PolicySetValidator{
+		MaxPolicies:        1000,
+		MaxTotalStatements: 10000,
+		DetectConflicts:    true,
+		policyValidator:    policyValidator,
+		coverage:           statementCoverage,
+	}
+}
+
+func (v *PolicySetValidator) ValidateSet(policies []policy.Policy) []ValidationError {
+	var errors []ValidationError
+
+	if v.MaxPolicies > 0 && len(policies) > v.MaxPolicies {
+		errors = append(errors, ValidationError{
+			Field:    "Policies",
+			Message:  fmt.Sprintf("Policy set exceeds maximum number of policies (%d)", v.MaxPolicies),
+			Code:     CodeLimitExceeded,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("policies"),
+		})
+	}
+
+	totalStatements := 0
+	for _, p := range policies {
+		totalStatements += len(p.Statements)
+	}
+	if v.MaxTotalStatements > 0 && totalStatements > v.MaxTotalStatements {
+		errors = append(errors, ValidationError{
+			Field:    "Policies",
+			Message:  fmt.Sprintf("Policy set exceeds maximum total number of statements (%d)", v.MaxTotalStatements),
+			Code:     CodeLimitExceeded,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("policies"),
+		})
+	}
+
+	if v.policyValidator != nil {
+		for i, p := range policies {
+			for _, err := range v.policyValidator.Validate(p) {
+				err.PolicyID = p.ID
+				err.Pointer = JSONPointer("policies", i) + err.Pointer
+				errors = append(errors, err)
+			}
+		}
+	}
+
+	errors = append(errors, v.validateUniqueIDs(policies)...)
+	if v.DetectConflicts {
+		errors = append(errors, v.validateConflicts(policies)...)
+	}
+
+	return errors
+}
+
+func (v *PolicySetValidator) validateUniqueIDs(policies []policy.Policy) []ValidationError {
+	var errors []ValidationError
+	policyIDs := make(map[string]int)
+	statementIDs := make(map[string]string)
+
+	for i, p := range policies {
+		if p.ID != "" {
+			if first, exists := policyIDs[p.ID]; exists {
+				errors = append(errors, ValidationError{
+					Field:    "ID",
+					Message:  fmt.Sprintf("Policy ID %q is already used by the policy at index %d", p.ID, first),
+					Code:     CodeDuplicatePolicyID,
+					Severity: SeverityError,
+					Pointer:  JSONPointer("policies", i, "id"),
+					PolicyID: p.ID,
+				})
+			} else {
+				policyIDs[p.ID] = i
+			}
+		}
+
+		for j, statement := range p.Statements {
+			if statement.ID == "" {
+				continue
+			}
+			if owner, exists := statementIDs[statement.ID]; exists {
+				errors = append(errors, ValidationError{
+					Field:    fmt.Sprintf("Statements[%d].ID", j),
+					Message:  fmt.Sprintf("Statement ID %q is already used in policy %q", statement.ID, owner),
+					Code:     CodeDuplicateStatementID,
+					Severity: SeverityError,
+					Pointer:  JSONPointer("policies", i, "statements", j, "id"),
+					PolicyID: p.ID,
+				})
+				continue
+			}
+			statementIDs[statement.ID] = p.ID
+		}
+	}
+
+	return errors
+}
+
+func (v *PolicySetValidator) validateConflicts(policies []policy.Policy) []ValidationError {
+	var errors []ValidationError
+
+	for i, p := range policies {
+		for j, allow := range p.Statements {
+			if allow.Effect != policy.Allow {
+				continue
+			}
+			for k, other := range policies {
+				if k == i {
+					continue
+				}
+				deny, found := v.overridingDeny(other, allow)
+				if !found {
+					continue
+				}
+				errors = append(errors, ValidationError{
+					Field:    fmt.Sprintf("Statements[%d]", j),
+					Message:  fmt.Sprintf("Allow statement %q is always overridden by Deny statement %q in policy %q", allow.ID, deny.ID, other.ID),
+					Code:     CodeConflictingStatements,
+					Severity: SeverityWarning,
+					Pointer:  JSONPointer("policies", i, "statements", j),
+					PolicyID: p.ID,
+				})
+				break
+			}
+		}
+	}
+
+	return errors
+}
+
+func (v *PolicySetValidator) overridingDeny(p policy.Policy, allow policy.Statement) (policy.Statement, bool) {
+	for _, deny := range p.Statements {
+		if deny.Effect != policy.Deny || len(deny.Conditions) > 0 {
+			continue
+		}
+		if v.coverage.StatementCovers(deny, allow) {
+			return deny, true
+		}
+	}
+	return policy.Statement{}, false
+}
@@ -26,43 +26,61 @@ func (v *StatementValidator) ValidateStatement(statement policy.Statement, index
 
 	if statement.Effect != policy.Allow && statement.Effect != policy.Deny {
 		errors = append(errors, ValidationError{
-			Field:   fieldPrefix + "Effect",
-			Message: "Effect must be either Allow or Deny",
+			Field:    fieldPrefix + "Effect",
+			Message:  "Effect must be either Allow or Deny",
+			Code:     CodeInvalidEffect,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("statements", index, "effect"),
 		})
 	}
 
 	if len(statement.Actions) == 0 {
 		errors = append(errors, ValidationError{
-			Field:   fieldPrefix + "Actions",
-			Message: "Statement must have at least one action",
+			Field:    fieldPrefix + "Actions",
+			Message:  "Statement must have at least one action",
+			Code:     CodeRequired,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("statements", index, "actions"),
 		})
 	}
 
 	if v.MaxActionsPerStm > 0 && len(statement.Actions) > v.MaxActionsPerStm {
 		errors = append(errors, ValidationError{
-			Field:   fieldPrefix + "Actions",
-			Message: fmt.Sprintf("Statement exceeds maximum number of actions (%d)", v.MaxActionsPerStm),
+			Field:    fieldPrefix + "Actions",
+			Message:  fmt.Sprintf("Statement exceeds maximum number of actions (%d)", v.MaxActionsPerStm),
+			Code:     CodeLimitExceeded,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("statements", index, "actions"),
 		})
 	}
 
 	if len(statement.Resources) == 0 {
 		errors = append(errors, ValidationError{
-			Field:   fieldPrefix + "Resources",
-			Message: "Statement must have at least one resource",
+			Field:    fieldPrefix + "Resources",
+			Message:  "Statement must have at least one resource",
+			Code:     CodeRequired,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("statements", index, "resources"),
 		})
 	}
 
 	if v.MaxResourcesPerStm > 0 && len(statement.Resources) > v.MaxResourcesPerStm {
 		errors = append(errors, ValidationError{
-			Field:   fieldPrefix + "Resources",
-			Message: fmt.Sprintf("Statement exceeds maximum number of resources (%d)", v.MaxResourcesPerStm),
+			Field:    fieldPrefix + "Resources",
+			Message:  fmt.Sprintf("Statement exceeds maximum number of resources (%d)", v.MaxResourcesPerStm),
+			Code:     CodeLimitExceeded,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("statements", index, "resources"),
 		})
 	}
 
 	if v.MaxConditionsPerStm > 0 && len(statement.Conditions) > v.MaxConditionsPerStm {
 		errors = append(errors, ValidationError{
-			Field:   fieldPrefix + "Conditions",
-			Message: fmt.Sprintf("Statement exceeds maximum number of conditions (%d)", v.MaxConditionsPerStm),
+			Field:    fieldPrefix + "Conditions",
+			Message:  fmt.Sprintf("Statement exceeds maximum number of conditions (%d)", v.MaxConditionsPerStm),
+			Code:     CodeLimitExceeded,
+			Severity: SeverityError,
+			Pointer:  JSONPointer("statements", index, "conditions"),
 		})
 	}
 
@@ -0,0 +1,376 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	yamlSyntaxErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+	yamlTypeErrorPattern   = regexp.MustCompile(`^line \d+: `)
+	jsonNumberPattern      = regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)(?:\.[0-9]+)?(?:[eE][+-]?[0-9]+)?$`)
+)
+
+// YAMLError reports a problem decoding a YAML policy document at a node position.
+type YAMLError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *YAMLError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("yaml: line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("yaml: line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+func nodeError(node *yaml.Node, message string) error {
+	return &YAMLError{Line: node.Line, Column: node.Column, Message: message}
+}
+
+func (p Policy) MarshalYAML() (interface{}, error) {
+	aux := struct {
+		Version     string             `yaml:"version"`
+		ID          string             `yaml:"id"`
+		Name        string             `yaml:"name"`
+		Description string             `yaml:"description,omitempty"`
+		Statements  []Statement        `yaml:"statements"`
+		CreatedAt   string             `yaml:"created_at"`
+		UpdatedAt   string             `yaml:"updated_at,omitempty"`
+		Template    *TemplateReference `yaml:"template,omitempty"`
+	}{
+		Version:     p.Version,
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Statements:  p.Statements,
+		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
+		Template:    p.Template,
+	}
+	if !p.UpdatedAt.IsZero() {
+		aux.UpdatedAt = p.UpdatedAt.Format(time.RFC3339)
+	}
+	return aux, nil
+}
+
+func (p *Policy) UnmarshalYAML(node *yaml.Node) error {
+	var createdAt, updatedAt string
+	var createdAtNode, updatedAtNode *yaml.Node
+	err := decodeYAMLMapping(node, map[string]interface{}{
+		"version":     &p.Version,
+		"id":          &p.ID,
+		"name":        &p.Name,
+		"description": &p.Description,
+		"statements":  &p.Statements,
+		"created_at":  &createdAt,
+		"updated_at":  &updatedAt,
+		"template":    &p.Template,
+	}, map[string]**yaml.Node{
+		"created_at": &createdAtNode,
+		"updated_at": &updatedAtNode,
+	})
+	if err != nil {
+		return err
+	}
+
+	if createdAt == "" {
+		return nodeError(node, "created_at is required")
+	}
+	if p.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return nodeError(createdAtNode, "invalid created_at format, must be RFC3339")
+	}
+
+	if updatedAt != "" {
+		if p.UpdatedAt, err = time.Parse(time.RFC3339, updatedAt); err != nil {
+			return nodeError(updatedAtNode, "invalid updated_at format, must be RFC3339")
+		}
+	}
+
+	return nil
+}
+
+func (s *Statement) UnmarshalYAML(node *yaml.Node) error {
+	return decodeYAMLMapping(node, map[string]interface{}{
+		"id":         &s.ID,
+		"effect":     &s.Effect,
+		"principals": &s.Principals,
+		"actions":    &s.Actions,
+		"resources":  &s.Resources,
+		"conditions": &s.Conditions,
+	}, nil)
+}
+
+func (r TemplateReference) MarshalYAML() (interface{}, error) {
+	aux := struct {
+		ID         string     `yaml:"id"`
+		Version    string     `yaml:"version,omitempty"`
+		Parameters *yaml.Node `yaml:"parameters,omitempty"`
+	}{ID: r.ID, Version: r.Version}
+	if len(r.Parameters) > 0 {
+		parameters, err := yamlValueNode(r.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		aux.Parameters = parameters
+	}
+	return aux, nil
+}
+
+func (r *TemplateReference) UnmarshalYAML(node *yaml.Node) error {
+	var parametersNode *yaml.Node
+	err := decodeYAMLMapping(node, map[string]interface{}{
+		"id":      &r.ID,
+		"version": &r.Version,
+	}, map[string]**yaml.Node{
+		"parameters": &parametersNode,
+	})
+	if err != nil || parametersNode == nil {
+		return err
+	}
+	value, err := decodeYAMLValue(parametersNode)
+	if err != nil {
+		return err
+	}
+	parameters, ok := value.(map[string]interface{})
+	if !ok {
+		return nodeError(parametersNode, fmt.Sprintf("field parameters: expected a mapping, got %s", yamlKindName(parametersNode)))
+	}
+	r.Parameters = parameters
+	return nil
+}
+
+// MarshalYAML writes json.Number values as YAML numbers.
+func (c Condition) MarshalYAML() (interface{}, error) {
+	value, err := yamlValueNode(c.Value)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		Operator ConditionOperator `yaml:"operator"`
+		Key      ConditionKey      `yaml:"key"`
+		Value    *yaml.Node        `yaml:"value"`
+	}{c.Operator, c.Key, value}, nil
+}
+
+// UnmarshalYAML decodes numeric values as json.Number.
+func (c *Condition) UnmarshalYAML(node *yaml.Node) error {
+	var valueNode *yaml.Node
+	err := decodeYAMLMapping(node, map[string]interface{}{
+		"operator": &c.Operator,
+		"key":      &c.Key,
+	}, map[string]**yaml.Node{
+		"value": &valueNode,
+	})
+	if err != nil {
+		return err
+	}
+	if valueNode != nil {
+		if c.Value, err = decodeYAMLValue(valueNode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeYAMLMapping(node *yaml.Node, targets map[string]interface{}, nodes map[string]**yaml.Node) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return nodeError(node, fmt.Sprintf("expected a mapping, got %s", yamlKindName(node)))
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if target, ok := nodes[key.Value]; ok {
+			*target = value
+		}
+		target, ok := targets[key.Value]
+		if !ok {
+			continue
+		}
+		if err := value.Decode(target); err != nil {
+			var yamlErr *YAMLError
+			if errors.As(err, &yamlErr) {
+				return err
+			}
+			return nodeError(value, fmt.Sprintf("field %s: %s", key.Value, yamlTypeErrorMessage(err)))
+		}
+	}
+	return nil
+}
+
+func yamlTypeErrorMessage(err error) string {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return err.Error()
+	}
+	messages := make([]string, len(typeErr.Errors))
+	for i, message := range typeErr.Errors {
+		messages[i] = yamlTypeErrorPattern.ReplaceAllString(message, "")
+	}
+	return strings.Join(messages, "; ")
+}
+
+func yamlKindName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return "a sequence"
+	case yaml.ScalarNode:
+		return fmt.Sprintf("scalar %q", node.Value)
+	case yaml.DocumentNode:
+		return "a document"
+	}
+	return "an unexpected node"
+}
+
+func decodeYAMLValue(node *yaml.Node) (interface{}, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return decodeYAMLValue(node.Alias)
+	case yaml.ScalarNode:
+		if (node.Tag == "!!int" || node.Tag == "!!float") && jsonNumberPattern.MatchString(node.Value) {
+			return json.Number(node.Value), nil
+		}
+	case yaml.SequenceNode:
+		items := make([]interface{}, len(node.Content))
+		for i, item := range node.Content {
+			value, err := decodeYAMLValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = value
+		}
+		return items, nil
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := decodeYAMLValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[node.Content[i].Value] = value
+		}
+		return m, nil
+	}
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil, nodeError(node, yamlTypeErrorMessage(err))
+	}
+	return value, nil
+}
+
+func yamlValueNode(value interface{}) (*yaml.Node, error) {
+	switch v := value.(type) {
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(string(v), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: string(v)}, nil
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range v {
+			child, err := yamlValueNode(item)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		return node, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, key := range keys {
+			child, err := yamlValueNode(v[key])
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+		}
+		return node, nil
+	}
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func unmarshalYAML(data string, target interface{}) error {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(data), &document); err != nil {
+		return yamlSyntaxError(err)
+	}
+	if len(document.Content) == 0 {
+		return &YAMLError{Line: 1, Message: "document is empty"}
+	}
+	if err := document.Content[0].Decode(target); err != nil {
+		var yamlErr *YAMLError
+		if errors.As(err, &yamlErr) {
+			return err
+		}
+		return nodeError(document.Content[0], yamlTypeErrorMessage(err))
+	}
+	return nil
+}
+
+func marshalYAML(value interface{}) (string, error) {
+	var b strings.Builder
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func yamlSyntaxError(err error) error {
+	match := yamlSyntaxErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+	line, _ := strconv.Atoi(match[1])
+	return &YAMLError{Line: line, Message: match[2]}
+}
+
+func FromYAML(yamlStr string) (Policy, error) {
+	var p Policy
+	err := unmarshalYAML(yamlStr, &p)
+	return p, err
+}
+
+func FromYAMLArray(yamlStr string) ([]Policy, error) {
+	var p []Policy
+	err := unmarshalYAML(yamlStr, &p)
+	return p, err
+}
+
+func (p Policy) ToYAML() (string, error) {
+	return marshalYAML(p)
+}
+
+func FromYAMLList(yamlStr string) ([]Policy, error) {
+	var pl PolicyList
+	err := unmarshalYAML(yamlStr, &pl)
+	return pl.Policies, err
+}
+
+func ToYAMLList(policies []Policy) (string, error) {
+	return marshalYAML(PolicyList{Policies: policies})
+}
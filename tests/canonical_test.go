@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+func TestPolicyHashIgnoresFormatting(t *testing.T) {
+	// Arrange
+	first := `{"version": "2023-01-01", "id": "p1", "name": "Orders",
+  "statements": [{"id": "s1", "effect": "Allow", "actions": ["read", "list", "read"], "resources": ["orders/*"],
+    "conditions": [
+      {"operator": "NumericLessThan", "key": "amount", "value": 1.50},
+      {"operator": "StringEquals", "key": "team", "value": "sales"}
+    ]}],
+  "created_at": "2023-05-01T12:00:00+02:00"}`
+	second := `{"created_at":"2023-05-01T10:00:00Z","statements":[{"conditions":[{"value":"sales","key":"team","operator":"StringEquals"},
+{"key":"amount","operator":"NumericLessThan","value":15e-1}],"resources":["orders/*"],"actions":["list","read"],"effect":"Allow","id":"s1"}],
+"name":"Orders","id":"p1","version":"2023-01-01"}`
+
+	a, err := policy.FromJSON(first)
+	if err != nil {
+		t.Fatalf("Failed to decode first policy: %v", err)
+	}
+	b, err := policy.FromJSON(second)
+	if err != nil {
+		t.Fatalf("Failed to decode second policy: %v", err)
+	}
+
+	// Act
+	hashA, errA := a.Hash()
+	hashB, errB := b.Hash()
+	canonical, _ := a.CanonicalJSON()
+
+	// Assert
+	if errA != nil || errB != nil {
+		t.Fatalf("Hash failed: %v %v", errA, errB)
+	}
+	if hashA != hashB {
+		t.Errorf("Equivalent policies should hash alike:\n%s", canonical)
+	}
+	if len(hashA) != 64 {
+		t.Errorf("Expected a hex SHA-256 digest, got %q", hashA)
+	}
+	if !strings.Contains(string(canonical), `"actions":["list","read"]`) ||
+		!strings.Contains(string(canonical), `"value":1.5}`) ||
+		!strings.Contains(string(canonical), `"created_at":"2023-05-01T10:00:00Z"`) {
+		t.Errorf("Unexpected canonical encoding: %s", canonical)
+	}
+	if etag, _ := a.ETag(); etag != `"`+hashA+`"` {
+		t.Errorf("Expected quoted hash as ETag, got %s", etag)
+	}
+}
+
+func TestPolicyHashDetectsChanges(t *testing.T) {
+	// Arrange
+	base := policy.Policy{
+		Version: policy.PolicyVersion,
+		ID:      "p1",
+		Statements: []policy.Statement{
+			{ID: "allow", Effect: policy.Allow, Actions: []policy.Action{"read"}, Resources: []policy.Resource{"*"}},
+			{ID: "deny", Effect: policy.Deny, Actions: []policy.Action{"delete"}, Resources: []policy.Resource{"*"}},
+		},
+	}
+	changedValue := base
+	changedValue.Statements = []policy.Statement{base.Statements[0], base.Statements[1]}
+	changedValue.Statements[0].Conditions = []policy.Condition{{Operator: policy.NumericEquals, Key: "n", Value: 10}}
+	sameValue := changedValue
+	sameValue.Statements = []policy.Statement{changedValue.Statements[0], changedValue.Statements[1]}
+	sameValue.Statements[0].Conditions = []policy.Condition{{Operator: policy.NumericEquals, Key: "n", Value: 10.0}}
+	reordered := base
+	reordered.Statements = []policy.Statement{base.Statements[1], base.Statements[0]}
+
+	// Act
+	baseHash, _ := base.Hash()
+	changedHash, _ := changedValue.Hash()
+	sameHash, _ := sameValue.Hash()
+	reorderedHash, _ := reordered.Hash()
+
+	// Assert
+	if baseHash == changedHash {
+		t.Error("Adding a condition should change the hash")
+	}
+	if changedHash != sameHash {
+		t.Error("10 and 10.0 should hash alike")
+	}
+	if baseHash == reorderedHash {
+		t.Error("Statement order should be part of the hash")
+	}
+}
+
+func TestConditionCanonicalNumbers(t *testing.T) {
+	cases := []struct {
+		name  string
+		a, b  policy.Condition
+		equal bool
+	}{
+		{
+			name:  "numeric string",
+			a:     policy.Condition{Operator: policy.NumericEquals, Key: "n", Value: "1.50"},
+			b:     policy.Condition{Operator: policy.NumericEquals, Key: "n", Value: 1.5},
+			equal: true,
+		},
+		{
+			name:  "numeric string list",
+			a:     policy.Condition{Operator: policy.NumericEquals, Key: "n", Value: []interface{}{"2e1", json.Number("3")}},
+			b:     policy.Condition{Operator: policy.NumericEquals, Key: "n", Value: []interface{}{20, "3.0"}},
+			equal: true,
+		},
+		{
+			name:  "large exponent",
+			a:     policy.Condition{Operator: policy.NumericEquals, Key: "n", Value: json.Number("1E1001")},
+			b:     policy.Condition{Operator: policy.NumericEquals, Key: "n", Value: json.Number("10e1000")},
+			equal: true,
+		},
+		{
+			name:  "string operator",
+			a:     policy.Condition{Operator: policy.StringEquals, Key: "n", Value: "1.50"},
+			b:     policy.Condition{Operator: policy.StringEquals, Key: "n", Value: "1.5"},
+			equal: false,
+		},
+	}
+
+	for _, c := range cases {
+		// Act
+		a, errA := c.a.CanonicalJSON()
+		b, errB := c.b.CanonicalJSON()
+
+		// Assert
+		if errA != nil || errB != nil {
+			t.Errorf("%s: CanonicalJSON failed: %v %v", c.name, errA, errB)
+			continue
+		}
+		if (string(a) == string(b)) != c.equal {
+			t.Errorf("%s: expected equal=%v, got %s and %s", c.name, c.equal, a, b)
+		}
+	}
+}
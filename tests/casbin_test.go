@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/casbin"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/convert"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+)
+
+func TestCasbinImport(t *testing.T) {
+	// Arrange
+	csv := `# legacy ACL
+p, admin, data/*, write
+p, *, data/public, read
+p, bob, data/secret, write, deny
+g, alice, admin
+g, bob, alice
+`
+
+	// Act
+	p, report, err := casbin.Import([]byte(csv), casbin.ImportOptions{ID: "legacy", Name: "Legacy"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(p.Statements) != 3 {
+		t.Fatalf("Expected one statement per p row, got %+v", p.Statements)
+	}
+	admin := p.Statements[0]
+	if admin.ID != "line-2" || len(admin.Principals) != 3 || admin.Principals[1] != "alice" || admin.Principals[2] != "bob" {
+		t.Errorf("Role members should be added to the principals transitively, got %+v", admin)
+	}
+	if len(p.Statements[1].Principals) != 0 {
+		t.Errorf("The * subject should apply to every principal, got %v", p.Statements[1].Principals)
+	}
+	if p.Statements[2].Effect != policy.Deny {
+		t.Errorf("Expected deny effect, got %s", p.Statements[2].Effect)
+	}
+	if len(report.Unsupported()) != 0 {
+		t.Errorf("Unexpected unsupported constructs: %+v", report.Unsupported())
+	}
+
+	policyEvaluator := factory.NewEvaluatorFactory().CreatePolicyEvaluator(p)
+	cases := []struct {
+		principal string
+		action    policy.Action
+		resource  policy.Resource
+		allowed   bool
+	}{
+		{"alice", "write", "data/report", true},
+		{"carol", "write", "data/report", false},
+		{"carol", "read", "data/public", true},
+		{"bob", "write", "data/secret", false},
+		{"alice", "write", "data/secret", true},
+	}
+	for _, c := range cases {
+		result := policyEvaluator.Evaluate(evaluator.Request{Principal: c.principal, Action: c.action, Resource: c.resource})
+		if result.Allowed != c.allowed {
+			t.Errorf("%s %s %s: expected allowed=%v, got %v", c.principal, c.action, c.resource, c.allowed, result.Allowed)
+		}
+	}
+}
+
+func TestCasbinImportReportsUnsupportedRows(t *testing.T) {
+	// Arrange
+	csv := "p, alice, data1, read\np, alice, domain1, data1, read, allow\ng, alice, admin, domain1\np2, alice, data1\n"
+
+	// Act
+	_, report, err := casbin.Import([]byte(csv), casbin.ImportOptions{})
+	skipped, _, skipErr := casbin.Import([]byte(csv), casbin.ImportOptions{SkipUnsupported: true})
+
+	// Assert
+	var conversionErr *convert.ConversionError
+	if !errors.As(err, &conversionErr) {
+		t.Fatalf("Expected a ConversionError, got %v", err)
+	}
+	lines := []int{}
+	for _, issue := range report.Unsupported() {
+		lines = append(lines, issue.Line)
+	}
+	if len(lines) != 3 || lines[0] != 2 || lines[1] != 3 || lines[2] != 4 {
+		t.Errorf("Expected unsupported rows on lines 2-4, got %v", lines)
+	}
+	if skipErr != nil || len(skipped.Statements) != 1 {
+		t.Errorf("SkipUnsupported should keep the supported row, got %v %+v", skipErr, skipped.Statements)
+	}
+}
+
+func TestCasbinExport(t *testing.T) {
+	// Arrange
+	policies := []policy.Policy{{
+		ID: "docs",
+		Statements: []policy.Statement{
+			{
+				Effect:     policy.Allow,
+				Principals: []policy.Principal{"alice", "bob"},
+				Actions:    []policy.Action{"read", "write"},
+				Resources:  []policy.Resource{"docs/*"},
+			},
+			{Effect: policy.Deny, Actions: []policy.Action{"delete"}, Resources: []policy.Resource{"docs/a,b"}},
+			{
+				Effect:     policy.Allow,
+				Actions:    []policy.Action{"read"},
+				Resources:  []policy.Resource{"*"},
+				Conditions: []policy.Condition{{Operator: policy.Bool, Key: "mfa", Value: true}},
+			},
+		},
+	}}
+
+	// Act
+	_, _, err := casbin.Export(policies, casbin.ExportOptions{})
+	data, report, skipErr := casbin.Export(policies, casbin.ExportOptions{SkipUnsupported: true})
+
+	// Assert
+	var conversionErr *convert.ConversionError
+	if !errors.As(err, &conversionErr) || !strings.Contains(err.Error(), "/policies/0/statements/2/conditions") {
+		t.Errorf("Conditions should be reported as unsupported, got %v", err)
+	}
+	if skipErr != nil || len(report.Issues) != 1 {
+		t.Fatalf("Expected the conditional Allow statement to be skipped with a warning, got %v %+v", skipErr, report.Issues)
+	}
+	expected := `p, alice, docs/*, read, allow
+p, alice, docs/*, write, allow
+p, bob, docs/*, read, allow
+p, bob, docs/*, write, allow
+p, *, "docs/a,b", delete, deny
+`
+	if string(data) != expected {
+		t.Errorf("Unexpected CSV:\n%s", data)
+	}
+
+	roundTrip, _, err := casbin.Import(data, casbin.ImportOptions{})
+	if err != nil || len(roundTrip.Statements) != 5 || roundTrip.Statements[4].Resources[0] != "docs/a,b" {
+		t.Errorf("Exported CSV should import back, got %v %+v", err, roundTrip.Statements)
+	}
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/catalog"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+func newDocumentsCatalog(t *testing.T) *catalog.Catalog {
+	cat := catalog.NewCatalog()
+	err := cat.RegisterService(catalog.Service{
+		Name: "documents",
+		ResourceTypes: []catalog.ResourceTypeDefinition{
+			{Name: "document", ConditionKeys: []policy.ConditionKey{"document.owner"}},
+			{Name: "folder"},
+		},
+		Actions: []catalog.ActionDefinition{
+			{Name: "read", ResourceTypes: []string{"document"}},
+			{Name: "readMetadata", ResourceTypes: []string{"document", "folder"}},
+			{Name: "share", ResourceTypes: []string{"document"}, ConditionKeys: []policy.ConditionKey{"share.recipient"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	cat.RegisterGlobalConditionKeys("request.time")
+	return cat
+}
+
+func TestCatalogRegistration(t *testing.T) {
+	// Arrange
+	cat := newDocumentsCatalog(t)
+
+	// Act & Assert
+	if _, ok := cat.Action("documents:read"); !ok {
+		t.Errorf("Actions should be registered under their qualified name")
+	}
+
+	if !cat.SupportsConditionKey("documents:read", "document.owner") {
+		t.Errorf("Resource type condition keys should apply to its actions")
+	}
+
+	if cat.SupportsConditionKey("documents:read", "share.recipient") {
+		t.Errorf("Action-specific condition keys should not apply to other actions")
+	}
+
+	if err := cat.RegisterService(catalog.Service{Name: "documents"}); err == nil {
+		t.Errorf("Registering a service twice should fail")
+	}
+
+	err := cat.RegisterService(catalog.Service{
+		Name:    "billing",
+		Actions: []catalog.ActionDefinition{{Name: "pay", ResourceTypes: []string{"invoice"}}},
+	})
+	if err == nil {
+		t.Errorf("Actions referring to unknown resource types should be rejected")
+	}
+}
+
+func TestValidateAgainstCatalog(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	validatorFactory := factory.NewValidatorFactoryWithCatalog(newDocumentsCatalog(t))
+	policyValidator := validatorFactory.CreatePolicyValidator()
+
+	valid := policyFactory.CreatePolicy("policy-1", "Documents",
+		policyFactory.CreateStatement("statement-1", policy.Allow,
+			[]policy.Action{"documents:read*"}, []policy.Resource{"document:*"}),
+	)
+	valid.Statements[0].Conditions = []policy.Condition{
+		{Operator: policy.StringEquals, Key: "document.owner", Value: "user-1"},
+		{Operator: policy.DateLessThan, Key: "request.time", Value: "2030-01-01T00:00:00Z"},
+	}
+
+	invalid := policyFactory.CreatePolicy("policy-2", "Typos",
+		policyFactory.CreateStatement("statement-1", policy.Allow,
+			[]policy.Action{"documents:raed", "documents:write*", "documents:read"},
+			[]policy.Resource{"folder:reports", "invoice:1"}),
+	)
+	invalid.Statements[0].Conditions = []policy.Condition{
+		{Operator: policy.StringEquals, Key: "share.recipient", Value: "user-2"},
+	}
+
+	// Act
+	validErrs := policyValidator.Validate(valid)
+	invalidErrs := policyValidator.Validate(invalid)
+
+	// Assert
+	if len(validErrs) > 0 {
+		t.Errorf("Policy using catalog entries should be valid: %v", validErrs)
+	}
+
+	expected := map[string]validator.ErrorCode{
+		"/statements/0/actions/0":        validator.CodeUnknownAction,
+		"/statements/0/actions/1":        validator.CodeNoMatchingAction,
+		"/statements/0/resources/0":      validator.CodeResourceTypeNotApplicable,
+		"/statements/0/resources/1":      validator.CodeUnknownResourceType,
+		"/statements/0/conditions/0/key": validator.CodeConditionKeyNotApplicable,
+	}
+	if len(invalidErrs) != len(expected) {
+		t.Errorf("Expected %d errors, got %v", len(expected), invalidErrs)
+	}
+	for _, err := range invalidErrs {
+		if expected[err.Pointer] != err.Code {
+			t.Errorf("Unexpected error %s at %s: %s", err.Code, err.Pointer, err.Message)
+		}
+	}
+}
+
+func TestCatalogServiceJSONUsesSnakeCase(t *testing.T) {
+	// Arrange
+	data := []byte(`{
+		"name": "documents",
+		"resource_types": [{"name": "document", "condition_keys": ["document.owner"]}],
+		"actions": [{"name": "read", "resource_types": ["document"], "condition_keys": ["read.reason"]}]
+	}`)
+	var service catalog.Service
+
+	// Act
+	err := json.Unmarshal(data, &service)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to decode service: %v", err)
+	}
+	if len(service.ResourceTypes) != 1 || len(service.ResourceTypes[0].ConditionKeys) != 1 {
+		t.Errorf("Expected resource types with condition keys: %+v", service.ResourceTypes)
+	}
+	if len(service.Actions) != 1 || len(service.Actions[0].ResourceTypes) != 1 || len(service.Actions[0].ConditionKeys) != 1 {
+		t.Errorf("Expected actions with resource types and condition keys: %+v", service.Actions)
+	}
+}
@@ -0,0 +1,211 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/schema"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+func newOrderSchema(t *testing.T) *schema.ContextSchema {
+	contextSchema, err := schema.NewContextSchema(
+		schema.KeyDefinition{Key: "order.amount", Type: schema.TypeNumber, Required: true},
+		schema.KeyDefinition{Key: "user.role", Type: schema.TypeString},
+		schema.KeyDefinition{Key: "user.mfa", Type: schema.TypeBool},
+		schema.KeyDefinition{Key: "source.ip", Type: schema.TypeIP},
+		schema.KeyDefinition{Key: "user.groups", Type: schema.TypeList, ElementType: schema.TypeString},
+	)
+	if err != nil {
+		t.Fatalf("Failed to create context schema: %v", err)
+	}
+	return contextSchema
+}
+
+func TestValidateConditionsAgainstContextSchema(t *testing.T) {
+	// Arrange
+	contextSchema := newOrderSchema(t)
+	conditionValidator := validator.NewConditionValidatorWithSchema(condition.NewOperatorRegistry(), contextSchema)
+
+	// Act
+	mismatch := conditionValidator.ValidateCondition(policy.Condition{
+		Operator: policy.NumericEquals, Key: "user.role", Value: 1,
+	}, 0, 0)
+	compatible := conditionValidator.ValidateCondition(policy.Condition{
+		Operator: policy.NumericLessThan, Key: "order.amount", Value: 100,
+	}, 0, 0)
+	undeclared := conditionValidator.ValidateCondition(policy.Condition{
+		Operator: policy.StringEquals, Key: "user.team", Value: "a",
+	}, 0, 0)
+
+	// Assert
+	if len(mismatch) != 1 || mismatch[0].Code != validator.CodeConditionKeyTypeMismatch || mismatch[0].Pointer != "/statements/0/conditions/0/key" {
+		t.Errorf("Numeric operator on a string key should be rejected: %v", mismatch)
+	}
+
+	if len(compatible) > 0 {
+		t.Errorf("Numeric operator on a number key should be accepted: %v", compatible)
+	}
+
+	if len(undeclared) > 0 {
+		t.Errorf("Undeclared keys should be accepted by a non-strict schema: %v", undeclared)
+	}
+
+	// Act & Assert - Strict schemas reject undeclared keys
+	contextSchema.Strict = true
+	undeclared = conditionValidator.ValidateCondition(policy.Condition{
+		Operator: policy.StringEquals, Key: "user.team", Value: "a",
+	}, 0, 0)
+	if len(undeclared) != 1 || undeclared[0].Code != validator.CodeUndeclaredConditionKey {
+		t.Errorf("Strict schema should reject undeclared keys: %v", undeclared)
+	}
+}
+
+func TestCoerceRequestContext(t *testing.T) {
+	// Arrange
+	contextSchema := newOrderSchema(t)
+
+	// Act
+	coerced, err := contextSchema.Coerce(map[string]interface{}{
+		"order.amount": "150.5",
+		"user.mfa":     "true",
+		"source.ip":    "::ffff:10.0.0.1",
+		"user.groups":  []string{"admins"},
+		"request.id":   "abc",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Context should be coerced without errors: %v", err)
+	}
+
+	if coerced["order.amount"] != json.Number("150.5") || coerced["user.mfa"] != true || coerced["source.ip"] != "10.0.0.1" {
+		t.Errorf("Incorrect coerced values: %v", coerced)
+	}
+
+	if coerced["request.id"] != "abc" {
+		t.Errorf("Undeclared keys should be passed through: %v", coerced)
+	}
+
+	// Act
+	_, err = contextSchema.Coerce(map[string]interface{}{"user.mfa": "maybe"})
+
+	// Assert
+	var contextErrs schema.ContextErrors
+	if !errors.As(err, &contextErrs) || len(contextErrs) != 2 {
+		t.Errorf("Expected missing and invalid key errors, got %v", err)
+	}
+}
+
+func TestEvaluatorWithContextSchema(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	evaluatorFactory := factory.NewEvaluatorFactory()
+	evaluatorFactory.ContextSchema = newOrderSchema(t)
+	orderPolicy := policyFactory.CreatePolicy("policy-1", "Orders",
+		policyFactory.CreateStatement("statement-1", policy.Allow,
+			[]policy.Action{"approve"}, []policy.Resource{"order:*"}),
+	)
+	orderPolicy.Statements[0].Conditions = []policy.Condition{
+		{Operator: policy.NumericLessThanEquals, Key: "order.amount", Value: 1000},
+		{Operator: policy.Bool, Key: "user.mfa", Value: true},
+	}
+	eval := evaluatorFactory.CreatePolicyEvaluator(orderPolicy)
+	req := evaluator.Request{Principal: "user-1", Action: "approve", Resource: "order:1"}
+
+	// Act & Assert - String values are coerced to the declared types
+	req.Context = map[string]interface{}{"order.amount": "999", "user.mfa": "true"}
+	if result := eval.Evaluate(req); !result.Allowed {
+		t.Errorf("Coerced request should be allowed: %s", result.Reason)
+	}
+
+	// Act & Assert - Non-conforming contexts are denied with an error
+	req.Context = map[string]interface{}{"order.amount": "a lot"}
+	result := eval.Evaluate(req)
+	if result.Allowed || result.Err == nil {
+		t.Errorf("Invalid context should be denied with an error, got %+v", result)
+	}
+}
+
+func TestContextSchemaDateAndDurationKeys(t *testing.T) {
+	// Arrange
+	contextSchema, err := schema.NewContextSchema(
+		schema.KeyDefinition{Key: "order.amount", Type: schema.TypeNumber},
+		schema.KeyDefinition{Key: "request.time", Type: schema.TypeDate},
+	)
+	if err != nil {
+		t.Fatalf("Failed to create context schema: %v", err)
+	}
+	conditionValidator := validator.NewConditionValidatorWithSchema(condition.NewOperatorRegistry(), contextSchema)
+
+	// Act
+	durationOnNumber := conditionValidator.ValidateCondition(policy.Condition{
+		Operator: policy.DurationLessThan, Key: "order.amount", Value: "1h",
+	}, 0, 0)
+	_, relativeErr := contextSchema.Coerce(map[string]interface{}{"request.time": "now-1h"})
+	coerced, absoluteErr := contextSchema.Coerce(map[string]interface{}{"request.time": "2024-01-02T03:04:05Z"})
+
+	// Assert
+	if len(durationOnNumber) != 1 || durationOnNumber[0].Code != validator.CodeConditionKeyTypeMismatch {
+		t.Errorf("Duration operator on a number key should be rejected: %v", durationOnNumber)
+	}
+	if relativeErr == nil {
+		t.Errorf("Relative time expressions should not be accepted as context dates")
+	}
+	if absoluteErr != nil {
+		t.Errorf("Absolute context dates should be coerced: %v", absoluteErr)
+	} else if _, ok := coerced["request.time"].(time.Time); !ok {
+		t.Errorf("Expected a time.Time, got %T", coerced["request.time"])
+	}
+}
+
+func TestKeyDefinitionJSONUsesSnakeCase(t *testing.T) {
+	// Arrange
+	var definition schema.KeyDefinition
+
+	// Act
+	err := json.Unmarshal([]byte(`{"key": "user.groups", "type": "list", "element_type": "string"}`), &definition)
+
+	// Assert
+	if err != nil || definition.ElementType != schema.TypeString {
+		t.Errorf("Expected element_type to be decoded: %+v, %v", definition, err)
+	}
+}
+
+func TestListOperatorsOnListKeys(t *testing.T) {
+	// Arrange
+	contextSchema := newOrderSchema(t)
+	conditionValidator := validator.NewConditionValidatorWithSchema(condition.NewOperatorRegistry(), contextSchema)
+	compositeEvaluator := condition.NewCompositeEvaluator()
+	contains := policy.Condition{Operator: policy.ListContains, Key: "user.groups", Value: "admins"}
+	notContains := policy.Condition{Operator: policy.ListNotContains, Key: "user.groups", Value: "guests"}
+	context := map[string]interface{}{"user.groups": []interface{}{"staff", "admins"}}
+
+	// Act
+	listErrs := conditionValidator.ValidateCondition(contains, 0, 0)
+	stringErrs := conditionValidator.ValidateCondition(policy.Condition{Operator: policy.StringEquals, Key: "user.groups", Value: "admins"}, 0, 0)
+	scalarErrs := conditionValidator.ValidateCondition(policy.Condition{Operator: policy.ListContains, Key: "user.role", Value: "admin"}, 0, 0)
+
+	// Assert
+	if len(listErrs) > 0 {
+		t.Errorf("ListContains should apply to a list key: %v", listErrs)
+	}
+	if len(stringErrs) != 1 || stringErrs[0].Code != validator.CodeConditionKeyTypeMismatch {
+		t.Errorf("StringEquals should not apply to a list key: %v", stringErrs)
+	}
+	if len(scalarErrs) != 1 || scalarErrs[0].Code != validator.CodeConditionKeyTypeMismatch {
+		t.Errorf("ListContains should not apply to a string key: %v", scalarErrs)
+	}
+	if !compositeEvaluator.Evaluate(contains, context) || !compositeEvaluator.Evaluate(notContains, context) {
+		t.Errorf("Expected list conditions to match %v", context)
+	}
+	if compositeEvaluator.Evaluate(contains, map[string]interface{}{"user.groups": []string{"staff"}}) {
+		t.Errorf("ListContains should not match a list without the value")
+	}
+}
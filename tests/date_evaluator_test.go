@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+)
+
+func TestDateEvaluatorRelativeExpressions(t *testing.T) {
+	// Arrange
+	now := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	dateEvaluator := condition.NewDateEvaluatorWithClock(condition.NewFixedClock(now))
+
+	// Act & Assert - Token issued within the last 15 minutes
+	if !dateEvaluator.GreaterThan("2023-05-01T10:20:00Z", "now-15m") {
+		t.Errorf("10:20 should be after now-15m")
+	}
+
+	if dateEvaluator.GreaterThan("2023-05-01T10:10:00Z", "now-15m") {
+		t.Errorf("10:10 should not be after now-15m")
+	}
+
+	// Act & Assert - Start of day and offsets
+	if !dateEvaluator.Equals("2023-05-01T00:00:00Z", "now/d") {
+		t.Errorf("now/d should resolve to the start of the day")
+	}
+
+	if !dateEvaluator.Equals("2023-04-30T00:00:00Z", "now-1d/d") {
+		t.Errorf("now-1d/d should resolve to the start of the previous day")
+	}
+
+	if !dateEvaluator.LessThan("2023-05-02T10:29:00Z", "now+1d") {
+		t.Errorf("10:29 tomorrow should be before now+1d")
+	}
+
+	if !dateEvaluator.Equals(now, "now") {
+		t.Errorf("now should resolve to the clock time")
+	}
+
+	// Act & Assert - Invalid expressions never match
+	if dateEvaluator.Equals(now, "now-15x") || dateEvaluator.NotEquals(now, "now") {
+		t.Errorf("Invalid relative expression should not match")
+	}
+}
+
+func TestDateEvaluatorRejectsRelativeContextValues(t *testing.T) {
+	// Arrange
+	now := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	compositeEvaluator := condition.NewCompositeEvaluatorWithClock(condition.NewFixedClock(now))
+	recent := policy.Condition{Operator: policy.DateGreaterThan, Key: "token_issued_at", Value: "now-15m"}
+
+	// Act & Assert
+	for _, value := range []interface{}{"now", "now-1m", "today"} {
+		if compositeEvaluator.Evaluate(recent, map[string]interface{}{"token_issued_at": value}) {
+			t.Errorf("Relative context value %q should not be resolved against the clock", value)
+		}
+	}
+	if !compositeEvaluator.Evaluate(recent, map[string]interface{}{"token_issued_at": "2023-05-01T10:25:00Z"}) {
+		t.Errorf("Absolute context value within the last 15 minutes should match")
+	}
+}
+
+func TestDateEvaluatorEpochValues(t *testing.T) {
+	// Arrange
+	dateEvaluator := condition.NewDateEvaluator()
+	expected := "2023-05-01T10:00:00Z"
+
+	// Act & Assert
+	values := []interface{}{
+		int64(1682935200),
+		1682935200,
+		float64(1682935200),
+		int64(1682935200000),
+		json.Number("1682935200000"),
+	}
+	for _, value := range values {
+		if !dateEvaluator.Equals(value, expected) {
+			t.Errorf("Epoch value %v (%T) should equal %s", value, value, expected)
+		}
+	}
+}
+
+func TestEvaluatedAtUsesClock(t *testing.T) {
+	// Arrange
+	now := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	evaluatorFactory := factory.NewEvaluatorFactoryWithConditionFactory(
+		factory.NewConditionFactoryWithClock(condition.NewFixedClock(now)),
+	)
+	policyFactory := factory.NewPolicyFactory()
+	eval := evaluatorFactory.CreatePolicyEvaluator(policyFactory.CreatePolicy(
+		"policy-1",
+		"Read Policy",
+		policyFactory.CreateStatement(
+			"statement-1",
+			policy.Allow,
+			[]policy.Action{"read"},
+			[]policy.Resource{"resource:*"},
+		),
+	))
+
+	// Act
+	result := eval.Evaluate(evaluator.Request{Action: "read", Resource: "resource:doc1"})
+
+	// Assert
+	if !result.EvaluatedAt.Equal(now) {
+		t.Errorf("Incorrect EvaluatedAt: expected %v, got %v", now, result.EvaluatedAt)
+	}
+}
+
+type tickingClock struct {
+	now time.Time
+}
+
+func (c *tickingClock) Now() time.Time {
+	now := c.now
+	c.now = c.now.Add(time.Second)
+	return now
+}
+
+func TestDateEvaluatorReadsClockOnce(t *testing.T) {
+	// Arrange
+	now := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	dateEvaluator := condition.NewDateEvaluatorWithClock(&tickingClock{now: now})
+	hourAgo := now.Add(-time.Hour).Format(time.RFC3339)
+
+	// Act
+	greaterOrEqual := dateEvaluator.GreaterThanEquals(hourAgo, "now-1h")
+	lessOrEqual := dateEvaluator.LessThanEquals(now.Add(time.Second).Format(time.RFC3339), "now")
+
+	// Assert
+	if !greaterOrEqual {
+		t.Errorf("Expected a time equal to now-1h to satisfy GreaterThanEquals")
+	}
+	if !lessOrEqual {
+		t.Errorf("Expected a time equal to now to satisfy LessThanEquals")
+	}
+}
@@ -0,0 +1,304 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+)
+
+func TestDecisionCacheHitsAndMisses(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	evaluatorFactory := factory.NewEvaluatorFactory()
+	readPolicy := policyFactory.CreatePolicy(
+		"policy-1",
+		"Read Policy",
+		policyFactory.CreateStatement(
+			"statement-1",
+			policy.Allow,
+			[]policy.Action{"read"},
+			[]policy.Resource{"resource:*"},
+		),
+	)
+	readPolicy.Statements[0].Conditions = []policy.Condition{
+		{Operator: policy.StringEquals, Key: "user.role", Value: "admin"},
+	}
+	cache := evaluator.NewDecisionCache(100, time.Minute)
+	eval := evaluatorFactory.CreateCachedPolicyEvaluator(cache, readPolicy)
+
+	req := evaluator.Request{
+		Principal: "user-1",
+		Action:    "read",
+		Resource:  "resource:doc1",
+		Context: map[string]interface{}{
+			"user.role":  "admin",
+			"request.id": "abc",
+		},
+	}
+
+	// Act
+	first := eval.Evaluate(req)
+	req.Context = map[string]interface{}{
+		"user.role":  "admin",
+		"request.id": "def",
+	}
+	second := eval.Evaluate(req)
+
+	// Assert
+	if !first.Allowed || !second.Allowed {
+		t.Fatalf("Both requests should be allowed, got %v and %v", first.Allowed, second.Allowed)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("Context keys unused by policies should not affect the cache key: %+v", stats)
+	}
+
+	if stats.HitRatio() != 0.5 {
+		t.Errorf("Incorrect hit ratio: expected 0.5, got %v", stats.HitRatio())
+	}
+
+	// Act - A different value for a referenced key is a separate entry
+	req.Context = map[string]interface{}{"user.role": "guest"}
+	third := eval.Evaluate(req)
+
+	// Assert
+	if third.Allowed {
+		t.Errorf("Request with guest role should not be allowed")
+	}
+
+	if cache.Stats().Misses != 2 {
+		t.Errorf("Expected a miss for a different condition value, got %+v", cache.Stats())
+	}
+}
+
+func TestDecisionCacheInvalidatedOnPolicyChange(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	evaluatorFactory := factory.NewEvaluatorFactory()
+	allowPolicy := policyFactory.CreatePolicy(
+		"policy-1",
+		"Allow Policy",
+		policyFactory.CreateStatement(
+			"statement-1",
+			policy.Allow,
+			[]policy.Action{"delete"},
+			[]policy.Resource{"resource:*"},
+		),
+	)
+	cache := evaluator.NewDefaultDecisionCache()
+	eval := evaluatorFactory.CreateCachedPolicyEvaluator(cache, allowPolicy)
+	req := evaluator.Request{Principal: "user-1", Action: "delete", Resource: "resource:doc1"}
+
+	if !eval.Evaluate(req).Allowed {
+		t.Fatalf("Request should be allowed before the deny policy is added")
+	}
+
+	// Act
+	eval.AddPolicy(policyFactory.CreatePolicy(
+		"policy-2",
+		"Deny Policy",
+		policyFactory.CreateStatement(
+			"statement-2",
+			policy.Deny,
+			[]policy.Action{"delete"},
+			[]policy.Resource{"resource:*"},
+		),
+	))
+	result := eval.Evaluate(req)
+
+	// Assert
+	if result.Allowed {
+		t.Errorf("Cached decision should be invalidated when the policy set changes")
+	}
+
+	if cache.Stats().Hits != 0 {
+		t.Errorf("No cache hits expected across policy changes, got %+v", cache.Stats())
+	}
+}
+
+func TestDecisionCacheSizeBound(t *testing.T) {
+	// Arrange
+	cache := evaluator.NewDecisionCache(2, time.Minute)
+	cache.Invalidate(nil)
+
+	// Act
+	for _, resource := range []policy.Resource{"a", "b", "c"} {
+		cache.Put(evaluator.Request{Action: "read", Resource: resource}, evaluator.Result{Allowed: true})
+	}
+
+	// Assert
+	stats := cache.Stats()
+	if stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("Cache should hold at most 2 entries: %+v", stats)
+	}
+
+	if _, ok := cache.Get(evaluator.Request{Action: "read", Resource: "a"}); ok {
+		t.Errorf("Least recently used entry should have been evicted")
+	}
+}
+
+func TestDecisionCachePolicyChangeDuringEvaluation(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	conditionFactory := factory.NewConditionFactory()
+	var eval evaluator.IPolicyEvaluator
+	changed := false
+	deny := policyFactory.CreatePolicy("deny", "Deny",
+		policyFactory.CreateStatement("deny", policy.Deny, []policy.Action{"read"}, []policy.Resource{"*"}))
+	err := conditionFactory.Operators.Register(condition.CustomOperator{
+		Operator: "ChangesPolicies",
+		Evaluate: func(contextValue, conditionValue interface{}) bool {
+			if !changed {
+				changed = true
+				done := make(chan struct{})
+				go func() {
+					eval.AddPolicy(deny)
+					close(done)
+				}()
+				<-done
+			}
+			return true
+		},
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	allow := policyFactory.CreatePolicy("allow", "Allow",
+		policyFactory.CreateStatement("allow", policy.Allow, []policy.Action{"read"}, []policy.Resource{"*"}))
+	allow.Statements[0].Conditions = []policy.Condition{{Operator: "ChangesPolicies", Key: "k", Value: "v"}}
+	cache := evaluator.NewDecisionCache(100, 0)
+	eval = factory.NewEvaluatorFactoryWithConditionFactory(conditionFactory).CreateCachedPolicyEvaluator(cache, allow)
+	req := evaluator.Request{Action: "read", Resource: "doc", Context: map[string]interface{}{"k": "v"}}
+
+	// Act
+	during := eval.Evaluate(req)
+	after := eval.Evaluate(req)
+
+	// Assert
+	if !during.Allowed {
+		t.Errorf("Expected the evaluation that started before the change to use the old policies")
+	}
+	if after.Allowed {
+		t.Errorf("Expected the decision made from the old policy set not to be cached")
+	}
+}
+
+func TestDecisionCacheConcurrentPolicyChange(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	evaluatorFactory := factory.NewEvaluatorFactory()
+	allow := policyFactory.CreatePolicy("allow", "Allow",
+		policyFactory.CreateStatement("allow", policy.Allow, []policy.Action{"read"}, []policy.Resource{"*"}))
+	deny := policyFactory.CreatePolicy("deny", "Deny",
+		policyFactory.CreateStatement("deny", policy.Deny, []policy.Action{"read"}, []policy.Resource{"*"}))
+	req := evaluator.Request{Action: "read", Resource: "doc"}
+
+	for round := 0; round < 50; round++ {
+		cache := evaluator.NewDecisionCache(100, 0)
+		eval := evaluatorFactory.CreateCachedPolicyEvaluator(cache, allow)
+		stop := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+						eval.Evaluate(req)
+						cache.Invalidate([]policy.Policy{allow})
+					}
+				}
+			}()
+		}
+
+		// Act
+		eval.AddPolicy(deny)
+		close(stop)
+		wg.Wait()
+		result := eval.Evaluate(req)
+
+		// Assert
+		if result.Allowed {
+			t.Fatalf("Round %d: a decision from the old policy set was served after AddPolicy", round)
+		}
+	}
+}
+
+func TestDecisionCacheScheduleBoundary(t *testing.T) {
+	// Arrange - Monday 2023-05-01 17:59 UTC
+	clock := condition.NewFixedClock(time.Date(2023, 5, 1, 17, 59, 0, 0, time.UTC))
+	evaluatorFactory := factory.NewEvaluatorFactoryWithConditionFactory(factory.NewConditionFactoryWithClock(clock))
+	policyFactory := factory.NewPolicyFactory()
+	officeHours := policyFactory.CreatePolicy("office-hours", "Office Hours",
+		policyFactory.CreateStatement("office-hours", policy.Allow, []policy.Action{"read"}, []policy.Resource{"*"}))
+	officeHours.Statements[0].Conditions = []policy.Condition{
+		{Operator: policy.ScheduleDayOfWeek, Value: "Mon-Fri"},
+		{Operator: policy.ScheduleTimeOfDay, Value: "09:00-18:00"},
+	}
+	cache := evaluator.NewDecisionCache(100, 0)
+	eval := evaluatorFactory.CreateCachedPolicyEvaluator(cache, officeHours)
+	req := evaluator.Request{Action: "read", Resource: "doc"}
+
+	// Act
+	before := eval.Evaluate(req)
+	clock.Set(time.Date(2023, 5, 1, 18, 0, 0, 0, time.UTC))
+	after := eval.Evaluate(req)
+
+	// Assert
+	if !before.Allowed {
+		t.Errorf("Request at 17:59 should be allowed")
+	}
+	if after.Allowed {
+		t.Errorf("Request at 18:00 should be denied, not served from the cache")
+	}
+	if stats := cache.Stats(); stats.Hits != 0 || stats.Entries != 0 {
+		t.Errorf("Clock-dependent decisions should not be cached: %+v", stats)
+	}
+}
+
+func TestDecisionCacheSharedBetweenEvaluators(t *testing.T) {
+	// Arrange
+	clock := condition.NewFixedClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	evaluatorFactory := factory.NewEvaluatorFactoryWithConditionFactory(factory.NewConditionFactoryWithClock(clock))
+	policyFactory := factory.NewPolicyFactory()
+	allow := policyFactory.CreatePolicy("allow", "Allow",
+		policyFactory.CreateStatement("allow", policy.Allow, []policy.Action{"read"}, []policy.Resource{"*"}))
+	deny := policyFactory.CreatePolicy("deny", "Deny",
+		policyFactory.CreateStatement("deny", policy.Deny, []policy.Action{"read"}, []policy.Resource{"*"}))
+	cache := evaluator.NewDecisionCacheWithClock(100, time.Minute, clock)
+	allowEval := evaluatorFactory.CreateCachedPolicyEvaluator(cache, allow)
+	denyEval := evaluatorFactory.CreateCachedPolicyEvaluator(cache, deny)
+	req := evaluator.Request{Action: "read", Resource: "doc"}
+
+	// Act
+	allowed := allowEval.Evaluate(req)
+	denied := denyEval.Evaluate(req)
+	clock.Set(time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC))
+	cachedAllowed := allowEval.Evaluate(req)
+	cachedDenied := denyEval.Evaluate(req)
+	clock.Set(time.Date(2024, 1, 1, 12, 2, 0, 0, time.UTC))
+	expired := allowEval.Evaluate(req)
+
+	// Assert
+	if !allowed.Allowed || denied.Allowed || !cachedAllowed.Allowed || cachedDenied.Allowed {
+		t.Errorf("Evaluators sharing a cache should keep their own decisions")
+	}
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Expirations != 1 {
+		t.Errorf("Expected two hits and an expiration by the cache clock: %+v", stats)
+	}
+	if !cachedAllowed.EvaluatedAt.Equal(time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)) {
+		t.Errorf("A cached result should carry the time of the evaluation, got %v", cachedAllowed.EvaluatedAt)
+	}
+	if !expired.Allowed {
+		t.Errorf("Expected the expired decision to be evaluated again")
+	}
+}
@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/diff"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+func diffPolicy() policy.Policy {
+	return policy.Policy{
+		Version:   policy.PolicyVersion,
+		ID:        "docs",
+		Name:      "Docs",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Statements: []policy.Statement{
+			{
+				ID:         "read",
+				Effect:     policy.Allow,
+				Actions:    []policy.Action{"docs:read"},
+				Resources:  []policy.Resource{"docs/*"},
+				Conditions: []policy.Condition{{Operator: policy.StringEquals, Key: "team", Value: "eng"}},
+			},
+			{ID: "legacy", Effect: policy.Allow, Actions: []policy.Action{"docs:*"}, Resources: []policy.Resource{"old/*"}},
+		},
+	}
+}
+
+func TestPolicyDiff(t *testing.T) {
+	// Arrange
+	old := diffPolicy()
+	new := diffPolicy()
+	new.Name = "Documents"
+	new.Statements = []policy.Statement{
+		{ID: "write", Effect: policy.Allow, Actions: []policy.Action{"docs:write"}, Resources: []policy.Resource{"docs/*"}},
+		{
+			ID:         "read",
+			Effect:     policy.Allow,
+			Actions:    []policy.Action{"docs:list", "docs:read"},
+			Resources:  []policy.Resource{"docs/*"},
+			Conditions: []policy.Condition{{Operator: policy.StringEquals, Key: "team", Value: "ops"}},
+		},
+	}
+
+	// Act
+	d, err := diff.Compare(old, new)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	patched, applyErr := diff.Apply(old, d.Patch, nil)
+
+	// Assert
+	expected := `~ name: "Docs" -> "Documents"
+- statement "legacy"
++ statement "write"
++ statement "read" action: "docs:list"
+- statement "read" condition: StringEquals team "eng"
++ statement "read" condition: StringEquals team "ops"
+`
+	if d.String() != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, d.String())
+	}
+	if applyErr != nil {
+		t.Fatalf("Apply failed: %v", applyErr)
+	}
+	expectedHash, _ := new.Hash()
+	if actualHash, _ := patched.Hash(); actualHash != expectedHash {
+		t.Errorf("Expected patched policy to equal the new one, got %s", mustJSON(patched))
+	}
+	if _, err := json.Marshal(d.Patch); err != nil {
+		t.Errorf("Expected patch to encode, got %v", err)
+	}
+}
+
+func TestPolicyPatchRevalidates(t *testing.T) {
+	// Arrange
+	patch, err := diff.ParsePatch([]byte(`[
+		{"op": "test", "path": "/statements/0/id", "value": "read"},
+		{"op": "replace", "path": "/statements/0/effect", "value": "Maybe"}
+	]`))
+	if err != nil {
+		t.Fatalf("ParsePatch failed: %v", err)
+	}
+	failing, _ := diff.ParsePatch([]byte(`[{"op": "test", "path": "/name", "value": "Other"}]`))
+
+	// Act
+	_, invalidErr := diff.Apply(diffPolicy(), patch, nil)
+	_, testErr := diff.Apply(diffPolicy(), failing, nil)
+
+	// Assert
+	var validationErrs validator.ValidationErrors
+	if !errors.As(invalidErr, &validationErrs) {
+		t.Errorf("Expected validation errors, got %v", invalidErr)
+	}
+	if testErr == nil {
+		t.Errorf("Expected failing test operation to abort the patch")
+	}
+}
+
+func TestPolicyDiffApplyRandom(t *testing.T) {
+	// Arrange
+	random := rand.New(rand.NewSource(7))
+	accept := validator.RuleFunc(func(policy.Policy) []validator.ValidationError { return nil })
+
+	for round := 0; round < 300; round++ {
+		old := randomPolicy(random, "p")
+		new := randomPolicy(random, "p")
+		random.Shuffle(len(new.Statements), func(i, j int) {
+			new.Statements[i], new.Statements[j] = new.Statements[j], new.Statements[i]
+		})
+		if random.Intn(2) == 0 {
+			new.Statements = append(new.Statements, policy.Statement{Effect: policy.Allow, Actions: []policy.Action{"read"}})
+		}
+
+		// Act
+		d, err := diff.Compare(old, new)
+		if err != nil {
+			t.Fatalf("Compare failed: %v", err)
+		}
+		patched, err := diff.Apply(old, d.Patch, accept)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Apply failed: %v\npatch: %s", err, mustJSON(d.Patch))
+		}
+		expected, _ := new.Hash()
+		if actual, _ := patched.Hash(); actual != expected {
+			t.Fatalf("Patched policy differs\nold: %s\nnew: %s\npatch: %s\ngot: %s",
+				mustJSON(old), mustJSON(new), mustJSON(d.Patch), mustJSON(patched))
+		}
+		if again, _ := diff.Compare(patched, new); !again.Empty() {
+			t.Fatalf("Expected no changes after applying the patch, got:\n%s", again)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/convert"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/iam"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+func TestIAMImport(t *testing.T) {
+	// Arrange
+	document := `{
+  "Version": "2012-10-17",
+  "Id": "s3-access",
+  "Statement": [
+    {
+      "Sid": "ReadReports",
+      "Effect": "Allow",
+      "Action": ["s3:GetObject", "s3:ListBucket"],
+      "Resource": "arn:aws:s3:::reports/*",
+      "Condition": {
+        "StringEquals": {"aws:PrincipalTag/team": ["finance", "audit"]},
+        "DateLessThan": {"aws:CurrentTime": "2030-01-01T00:00:00Z"},
+        "Bool": {"aws:SecureTransport": "true"}
+      }
+    }
+  ]
+}`
+	createdAt := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	p, report, err := iam.Import([]byte(document), iam.ImportOptions{Name: "S3", CreatedAt: createdAt})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if p.ID != "s3-access" || p.Name != "S3" || !p.CreatedAt.Equal(createdAt) {
+		t.Errorf("Unexpected policy fields: %+v", p)
+	}
+	if len(p.Statements) != 2 {
+		t.Fatalf("Multi-valued StringEquals should split the statement in two, got %d", len(p.Statements))
+	}
+	if p.Statements[0].ID != "ReadReports-1" || p.Statements[1].ID != "ReadReports-2" {
+		t.Errorf("Unexpected statement IDs: %q, %q", p.Statements[0].ID, p.Statements[1].ID)
+	}
+
+	first := p.Statements[0]
+	if len(first.Actions) != 2 || len(first.Resources) != 1 || first.Resources[0] != "arn:aws:s3:::reports/*/**" {
+		t.Errorf("Unexpected actions or resources: %+v", first)
+	}
+	if len(first.Conditions) != 3 {
+		t.Fatalf("Expected 3 conditions, got %+v", first.Conditions)
+	}
+	if first.Conditions[0].Operator != policy.Bool || first.Conditions[0].Value != true {
+		t.Errorf("Bool value should be converted to a boolean, got %#v", first.Conditions[0])
+	}
+	if first.Conditions[2].Value != "finance" || p.Statements[1].Conditions[2].Value != "audit" {
+		t.Errorf("Expected one statement per StringEquals value, got %+v", p.Statements)
+	}
+	if len(report.Unsupported()) != 0 || len(report.Issues) != 2 {
+		t.Errorf("Expected info notes about the resource and the split, got %+v", report.Issues)
+	}
+}
+
+func TestIAMImportReportsUnsupportedConstructs(t *testing.T) {
+	// Arrange
+	document := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "*"},
+    {"Effect": "Deny", "NotAction": "iam:*", "Resource": "*"},
+    {
+      "Effect": "Allow",
+      "Action": "ec2:*",
+      "Resource": "*",
+      "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}
+    },
+    {"Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::home/${aws:username}/*"}
+  ]
+}`
+
+	// Act
+	_, report, err := iam.Import([]byte(document), iam.ImportOptions{})
+	skipped, _, skipErr := iam.Import([]byte(document), iam.ImportOptions{SkipUnsupported: true})
+
+	// Assert
+	var conversionErr *convert.ConversionError
+	if !errors.As(err, &conversionErr) {
+		t.Fatalf("Expected a ConversionError, got %v", err)
+	}
+	paths := map[string]bool{}
+	for _, issue := range report.Unsupported() {
+		paths[issue.Path] = true
+	}
+	for _, path := range []string{"/Statement/1/NotAction", "/Statement/2/Condition/IpAddress", "/Statement/3/Resource/0"} {
+		if !paths[path] {
+			t.Errorf("Expected an unsupported construct at %s, got %+v", path, report.Unsupported())
+		}
+	}
+	if skipErr != nil || len(skipped.Statements) != 1 {
+		t.Errorf("SkipUnsupported should keep only the convertible statement, got %v %+v", skipErr, skipped.Statements)
+	}
+}
+
+func TestIAMExport(t *testing.T) {
+	// Arrange
+	p := policy.Policy{
+		Version: policy.PolicyVersion,
+		ID:      "export",
+		Statements: []policy.Statement{
+			{
+				ID:        "read-docs",
+				Effect:    policy.Allow,
+				Actions:   []policy.Action{"docs:Read"},
+				Resources: []policy.Resource{"docs/*"},
+				Conditions: []policy.Condition{
+					{Operator: policy.NumericLessThan, Key: "request.size", Value: json.Number("1024")},
+					{Operator: policy.StringEquals, Key: "user.role", Value: "editor"},
+					{Operator: policy.Bool, Key: "user.mfa", Value: true},
+				},
+			},
+			{
+				Effect:     policy.Deny,
+				Actions:    []policy.Action{"docs:Delete"},
+				Resources:  []policy.Resource{"*"},
+				Conditions: []policy.Condition{{Operator: policy.ScheduleTimeOfDay, Value: "09:00-17:00"}},
+			},
+		},
+	}
+
+	// Act
+	_, _, err := iam.Export(p, iam.ExportOptions{})
+	data, report, skipErr := iam.Export(p, iam.ExportOptions{SkipUnsupported: true})
+
+	// Assert
+	var conversionErr *convert.ConversionError
+	if !errors.As(err, &conversionErr) || !strings.Contains(err.Error(), "/statements/1/conditions/0/operator") {
+		t.Errorf("Schedule conditions should be reported as unsupported, got %v", err)
+	}
+	if skipErr != nil {
+		t.Fatalf("Export with SkipUnsupported failed: %v", skipErr)
+	}
+	if len(report.Unsupported()) != 1 {
+		t.Errorf("Expected one unsupported construct, got %+v", report.Issues)
+	}
+
+	doc, err := iam.ParseDocument(data)
+	if err != nil {
+		t.Fatalf("Exported document should parse: %v", err)
+	}
+	if doc.Version != iam.DocumentVersion || doc.ID != "export" || len(doc.Statement) != 1 {
+		t.Fatalf("Unexpected document: %s", data)
+	}
+	statement := doc.Statement[0]
+	if statement.Sid != "readdocs" {
+		t.Errorf("Expected Sid to be made alphanumeric, got %q", statement.Sid)
+	}
+	if got := statement.Condition["StringEquals"]["user.role"]; len(got) != 1 || got[0] != "editor" {
+		t.Errorf("Unexpected StringEquals condition, got %v", got)
+	}
+	if got := statement.Condition["Bool"]["user.mfa"]; len(got) != 1 || got[0] != "true" {
+		t.Errorf("Bool values should be exported as strings, got %v", got)
+	}
+
+	roundTrip, _, err := iam.ImportDocument(doc, iam.ImportOptions{})
+	if err != nil || len(roundTrip.Statements) != 1 || len(roundTrip.Statements[0].Conditions) != 3 {
+		t.Errorf("Exported document should import back, got %v %+v", err, roundTrip.Statements)
+	}
+}
+
+func TestIAMResourceWildcardsCrossSeparators(t *testing.T) {
+	// Arrange
+	document := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Sid": "DenySecrets", "Effect": "Deny", "Action": "s3:*", "Resource": "arn:aws:s3:::secret/*"},
+    {"Sid": "DenyConfigs", "Effect": "Deny", "Action": "s3:*", "Resource": "arn:aws:s3:::app/*/config"}
+  ]
+}`
+	evaluatorFactory := factory.NewEvaluatorFactory()
+
+	// Act
+	_, report, err := iam.Import([]byte(document), iam.ImportOptions{})
+	p, narrowedReport, narrowedErr := iam.Import([]byte(document), iam.ImportOptions{AllowNarrowedDeny: true})
+
+	// Assert
+	var conversionErr *convert.ConversionError
+	if !errors.As(err, &conversionErr) || len(report.Unsupported()) != 1 || report.Unsupported()[0].Path != "/Statement/1/Resource/0" {
+		t.Fatalf("A Deny with a narrowed wildcard should be rejected, got %v %+v", err, report.Issues)
+	}
+	if narrowedErr != nil {
+		t.Fatalf("AllowNarrowedDeny should import the statement: %v", narrowedErr)
+	}
+	var warnings int
+	for _, issue := range narrowedReport.Issues {
+		if issue.Severity == validator.SeverityWarning {
+			warnings++
+		}
+	}
+	if warnings != 1 {
+		t.Errorf("Expected a warning for the narrowed wildcard, got %+v", narrowedReport.Issues)
+	}
+
+	policyEvaluator := evaluatorFactory.CreatePolicyEvaluator(p)
+	result := policyEvaluator.Evaluate(evaluator.Request{
+		Principal: "user", Action: "s3:GetObject", Resource: "arn:aws:s3:::secret/a/b",
+	})
+	if result.Allowed || len(result.MatchedRules) != 1 || result.MatchedRules[0] != "DenySecrets" {
+		t.Errorf("The imported Deny should cover nested objects: %+v", result)
+	}
+
+	// Act - Export reverses the translation and rejects other uses of "**"
+	doc, exportReport, exportErr := iam.ExportDocument(p, iam.ExportOptions{})
+	p.Statements[0].Resources = []policy.Resource{"arn:aws:s3:::secret/**/key"}
+	_, _, unsupportedErr := iam.ExportDocument(p, iam.ExportOptions{})
+
+	// Assert
+	if exportErr != nil || doc.Statement[0].Resource[0] != "arn:aws:s3:::secret/*" {
+		t.Errorf("Expected the trailing wildcard to be exported as in IAM, got %v %+v", exportErr, doc.Statement)
+	}
+	if len(exportReport.Issues) != 1 || exportReport.Issues[0].Path != "/statements/1/resources/0" {
+		t.Errorf("Expected a warning for the widened wildcard, got %+v", exportReport.Issues)
+	}
+	if !errors.As(unsupportedErr, &conversionErr) || !strings.Contains(unsupportedErr.Error(), "/statements/0/resources/0") {
+		t.Errorf("A \"**\" segment should be reported as unsupported, got %v", unsupportedErr)
+	}
+}
+
+func TestIAMNegatedOperatorsWithMissingKeys(t *testing.T) {
+	// Arrange
+	document := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "DenyOutsideVpc",
+      "Effect": "Deny",
+      "Action": "s3:*",
+      "Resource": "*",
+      "Condition": {"StringNotEquals": {"aws:SourceVpc": "vpc-1"}}
+    }
+  ]
+}`
+	negated := policy.Statement{
+		ID:         "allow-non-guests",
+		Effect:     policy.Allow,
+		Actions:    []policy.Action{"docs:read"},
+		Resources:  []policy.Resource{"*"},
+		Conditions: []policy.Condition{{Operator: policy.StringNotEquals, Key: "user.role", Value: "guest"}},
+	}
+	p := policy.Policy{Version: policy.PolicyVersion, ID: "negated", Statements: []policy.Statement{negated}}
+	policyEvaluator := factory.NewEvaluatorFactory().CreatePolicyEvaluator(p)
+
+	// Act
+	_, importReport, importErr := iam.Import([]byte(document), iam.ImportOptions{})
+	_, exportReport, exportErr := iam.Export(p, iam.ExportOptions{})
+	missingKey := policyEvaluator.Evaluate(evaluator.Request{Principal: "user", Action: "docs:read", Resource: "a", Context: map[string]interface{}{}})
+
+	// Assert
+	var conversionErr *convert.ConversionError
+	if !errors.As(importErr, &conversionErr) || len(importReport.Unsupported()) != 1 ||
+		importReport.Unsupported()[0].Path != "/Statement/0/Condition/StringNotEquals" {
+		t.Errorf("A negated operator should fail the import, got %v %+v", importErr, importReport)
+	}
+	if !errors.As(exportErr, &conversionErr) || len(exportReport.Unsupported()) != 1 ||
+		exportReport.Unsupported()[0].Path != "/statements/0/conditions/0/operator" {
+		t.Errorf("A negated operator should fail the export, got %v %+v", exportErr, exportReport)
+	}
+	if missingKey.Allowed {
+		t.Errorf("StringNotEquals should be false for a missing key, unlike in IAM")
+	}
+}
@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/lint"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+func findingsByRule(findings []lint.Finding, ruleID string) []lint.Finding {
+	var result []lint.Finding
+	for _, finding := range findings {
+		if finding.RuleID == ruleID {
+			result = append(result, finding)
+		}
+	}
+	return result
+}
+
+func TestLintShadowedAndRedundantStatements(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	linter := lint.NewDefaultLinter(lint.Config{})
+	documentsPolicy := policyFactory.CreatePolicy(
+		"policy-1",
+		"Documents",
+		policyFactory.CreateStatement("deny-all-docs", policy.Deny,
+			[]policy.Action{"*"}, []policy.Resource{"document:*"}),
+		policyFactory.CreateStatement("allow-read-doc", policy.Allow,
+			[]policy.Action{"read"}, []policy.Resource{"document:report"}),
+		policyFactory.CreateStatement("allow-read", policy.Allow,
+			[]policy.Action{"read*"}, []policy.Resource{"file:*"}),
+		policyFactory.CreateStatement("allow-read-list", policy.Allow,
+			[]policy.Action{"readList"}, []policy.Resource{"file:a"}),
+		policyFactory.CreateStatement("allow-read", policy.Allow,
+			[]policy.Action{"read*"}, []policy.Resource{"file:*"}),
+	)
+
+	// Act
+	findings := linter.Lint(documentsPolicy)
+
+	// Assert
+	shadowed := findingsByRule(findings, lint.ShadowedStatementRuleID)
+	if len(shadowed) != 1 || shadowed[0].Path != "/statements/1" {
+		t.Errorf("Expected the document allow to be shadowed by the deny: %v", shadowed)
+	}
+
+	subsumed := findingsByRule(findings, lint.SubsumedStatementRuleID)
+	if len(subsumed) != 1 || subsumed[0].Path != "/statements/3" {
+		t.Errorf("Expected readList to be subsumed by read*: %v", subsumed)
+	}
+
+	duplicates := findingsByRule(findings, lint.DuplicateStatementRuleID)
+	if len(duplicates) != 1 || duplicates[0].Path != "/statements/4" {
+		t.Errorf("Expected the repeated statement to be reported once: %v", duplicates)
+	}
+
+	duplicateIDs := findingsByRule(findings, lint.DuplicateStatementIDRuleID)
+	if len(duplicateIDs) != 1 || duplicateIDs[0].Severity != validator.SeverityError || !strings.HasSuffix(duplicateIDs[0].Path, "/id") {
+		t.Errorf("Expected one duplicate statement ID error: %v", duplicateIDs)
+	}
+}
+
+func TestLintConditionsLimitShadowing(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	linter := lint.NewDefaultLinter(lint.Config{})
+	conditionalDeny := policyFactory.CreatePolicy(
+		"policy-1",
+		"Conditional Deny",
+		policyFactory.CreateStatement("deny-guests", policy.Deny,
+			[]policy.Action{"*"}, []policy.Resource{"*"}),
+		policyFactory.CreateStatement("allow-read", policy.Allow,
+			[]policy.Action{"read"}, []policy.Resource{"document:*"}),
+	)
+	conditionalDeny.Statements[0].Conditions = []policy.Condition{
+		{Operator: policy.StringEquals, Key: "user.role", Value: "guest"},
+	}
+
+	// Act
+	findings := linter.Lint(conditionalDeny)
+
+	// Assert
+	if shadowed := findingsByRule(findings, lint.ShadowedStatementRuleID); len(shadowed) != 0 {
+		t.Errorf("A conditional deny should not shadow an unconditional allow: %v", shadowed)
+	}
+}
+
+func TestLintAllowAllAndUnsatisfiableConditions(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	adminPolicy := policyFactory.CreatePolicy(
+		"policy-1",
+		"Admin",
+		policyFactory.CreateStatement("allow-all", policy.Allow,
+			[]policy.Action{"*"}, []policy.Resource{"*"}),
+		policyFactory.CreateStatement("impossible", policy.Allow,
+			[]policy.Action{"write"}, []policy.Resource{"document:*"}),
+	)
+	adminPolicy.Statements[1].Conditions = []policy.Condition{
+		{Operator: policy.NumericGreaterThan, Key: "request.size", Value: 10},
+		{Operator: policy.NumericLessThanEquals, Key: "request.size", Value: "10"},
+	}
+
+	// Act
+	findings := lint.NewDefaultLinter(lint.Config{}).Lint(adminPolicy)
+
+	// Assert
+	if allowAll := findingsByRule(findings, lint.AllowAllRuleID); len(allowAll) != 1 {
+		t.Errorf("Expected an allow-all finding: %v", allowAll)
+	}
+
+	unsatisfiable := findingsByRule(findings, lint.UnsatisfiableConditionRuleID)
+	if len(unsatisfiable) != 1 || unsatisfiable[0].Path != "/statements/1/conditions" {
+		t.Errorf("Expected conflicting numeric bounds to be reported: %v", unsatisfiable)
+	}
+
+	// Act - Rules can be disabled or have their severity changed
+	config := lint.Config{Rules: map[string]lint.RuleConfig{
+		lint.AllowAllRuleID:               {Severity: validator.SeverityError},
+		lint.UnsatisfiableConditionRuleID: {Disabled: true},
+		lint.SubsumedStatementRuleID:      {Disabled: true},
+	}}
+	findings = lint.NewDefaultLinter(config).Lint(adminPolicy)
+
+	// Assert
+	if len(findings) != 1 || findings[0].Severity != validator.SeverityError {
+		t.Errorf("Expected only the allow-all finding with error severity: %v", findings)
+	}
+}
+
+func TestLintCoverageFollowsExtendedGlob(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	docsPolicy := policyFactory.CreatePolicy(
+		"policy-1",
+		"Docs",
+		policyFactory.CreateStatement("deny-any", policy.Deny,
+			[]policy.Action{"*"}, []policy.Resource{"secret:*"}),
+		policyFactory.CreateStatement("allow-nested-secret", policy.Allow,
+			[]policy.Action{"docs:read/all"}, []policy.Resource{"secret:a"}),
+		policyFactory.CreateStatement("allow-docs", policy.Allow,
+			[]policy.Action{"docs:*"}, []policy.Resource{"document:*"}),
+		policyFactory.CreateStatement("allow-nested", policy.Allow,
+			[]policy.Action{"docs:read/all"}, []policy.Resource{"document:a"}),
+	)
+	conditionFactory := factory.NewConditionFactory()
+	conditionFactory.ExtendedGlob = true
+	linter := lint.NewDefaultLinterWithConditionProvider(lint.Config{}, factory.NewConditionFactoryAdapter(conditionFactory))
+
+	// Act
+	globFindings := linter.Lint(docsPolicy)
+	defaultFindings := lint.NewDefaultLinter(lint.Config{}).Lint(docsPolicy)
+
+	// Assert
+	if shadowed := findingsByRule(globFindings, lint.ShadowedStatementRuleID); len(shadowed) != 0 {
+		t.Errorf("Expected no shadowing when * does not cross separators: %v", shadowed)
+	}
+	if subsumed := findingsByRule(globFindings, lint.SubsumedStatementRuleID); len(subsumed) != 0 {
+		t.Errorf("Expected no subsumption when * does not cross separators: %v", subsumed)
+	}
+	if shadowed := findingsByRule(defaultFindings, lint.ShadowedStatementRuleID); len(shadowed) != 1 {
+		t.Errorf("Expected the default matcher to report shadowing: %v", shadowed)
+	}
+	if subsumed := findingsByRule(defaultFindings, lint.SubsumedStatementRuleID); len(subsumed) != 1 {
+		t.Errorf("Expected the default matcher to report subsumption: %v", subsumed)
+	}
+}
@@ -0,0 +1,170 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/migration"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+var legacyEffects = map[string]string{"allow": "Allow", "deny": "Deny"}
+
+func newTestMigrationRegistry(t *testing.T) *migration.Registry {
+	registry := migration.NewDefaultRegistry()
+	// 2022-01-01 used a single "action" string and lowercase effects.
+	err := registry.Register(migration.Migration{
+		From: "2022-01-01",
+		To:   "2022-06-01",
+		Migrate: func(doc migration.Document, report *migration.Report) error {
+			statements, _ := doc["statements"].([]interface{})
+			for i, item := range statements {
+				statement, ok := item.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("statement %d is not an object", i)
+				}
+				if action, ok := statement["action"].(string); ok {
+					statement["actions"] = []interface{}{action}
+					delete(statement, "action")
+					report.Record(fmt.Sprintf("/statements/%d/actions", i), "converted action to actions list")
+				}
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register migration: %v", err)
+	}
+	err = registry.Register(migration.Migration{
+		From: "2022-06-01",
+		To:   policy.PolicyVersion,
+		Migrate: func(doc migration.Document, report *migration.Report) error {
+			statements, _ := doc["statements"].([]interface{})
+			for i, item := range statements {
+				statement := item.(map[string]interface{})
+				effect, _ := statement["effect"].(string)
+				if capitalized, ok := legacyEffects[effect]; ok {
+					statement["effect"] = capitalized
+					report.Record(fmt.Sprintf("/statements/%d/effect", i), "capitalized effect")
+				}
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register migration: %v", err)
+	}
+	return registry
+}
+
+func TestMigratePolicyDocument(t *testing.T) {
+	// Arrange
+	registry := newTestMigrationRegistry(t)
+	legacy := `{
+		"version": "2022-01-01",
+		"id": "policy-1",
+		"name": "Legacy",
+		"created_at": "2022-01-01T00:00:00Z",
+		"statements": [{"id": "s1", "effect": "allow", "action": "read", "resources": ["doc:*"]}]
+	}`
+
+	// Act
+	migrated, report, err := registry.MigrateJSON([]byte(legacy))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Migration should succeed: %v", err)
+	}
+
+	if migrated.Version != policy.PolicyVersion || migrated.Statements[0].Effect != policy.Allow {
+		t.Errorf("Policy was not upgraded: %+v", migrated)
+	}
+
+	if len(migrated.Statements[0].Actions) != 1 || migrated.Statements[0].Actions[0] != "read" {
+		t.Errorf("Action was not converted: %v", migrated.Statements[0].Actions)
+	}
+
+	if len(report.Applied) != 2 || len(report.Changes) != 4 {
+		t.Errorf("Incorrect migration report: %s", report)
+	}
+
+	if errs := validator.NewDefaultValidator().Validate(migrated); len(errs) > 0 {
+		t.Errorf("Migrated policy should be valid: %v", errs)
+	}
+
+	// Act & Assert - Current documents are left untouched
+	current, _ := factory.NewPolicyFactory().CreatePolicy("policy-2", "Current",
+		factory.NewPolicyFactory().CreateStatement("s1", policy.Allow, []policy.Action{"read"}, []policy.Resource{"doc:*"}),
+	).ToJSON()
+	_, report, err = registry.MigrateJSON([]byte(current))
+	if err != nil || report.Migrated() {
+		t.Errorf("Current policy should not be migrated: %v %v", err, report)
+	}
+
+	// Act & Assert - Unknown versions are rejected
+	if _, _, err := registry.MigrateJSON([]byte(`{"version": "1999-01-01"}`)); err == nil {
+		t.Errorf("Unknown version should be rejected")
+	}
+
+	if versions := registry.SupportedVersions(); len(versions) != 3 {
+		t.Errorf("Incorrect supported versions: %v", versions)
+	}
+}
+
+func TestMigratePolicyListOnLoad(t *testing.T) {
+	// Arrange
+	registry := newTestMigrationRegistry(t)
+	list := `{"policies": [
+		{"version": "2022-06-01", "id": "legacy", "name": "Legacy", "created_at": "2022-06-01T00:00:00Z",
+		 "statements": [{"id": "s1", "effect": "deny", "actions": ["delete"], "resources": ["doc:*"]}]},
+		{"version": "` + policy.PolicyVersion + `", "id": "current", "name": "Current", "created_at": "2023-01-01T00:00:00Z",
+		 "statements": [{"id": "s1", "effect": "Allow", "actions": ["read"], "resources": ["doc:*"]}]}
+	]}`
+
+	// Act
+	policies, reports, err := registry.MigrateJSONList([]byte(list))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Loading should succeed: %v", err)
+	}
+
+	if len(policies) != 2 || len(reports) != 2 {
+		t.Fatalf("Expected 2 policies and reports, got %d and %d", len(policies), len(reports))
+	}
+
+	if policies[0].Version != policy.PolicyVersion || policies[0].Statements[0].Effect != policy.Deny {
+		t.Errorf("Legacy policy was not upgraded: %+v", policies[0])
+	}
+
+	if !reports[0].Migrated() || reports[1].Migrated() {
+		t.Errorf("Only the legacy policy should be migrated: %s, %s", reports[0], reports[1])
+	}
+
+	// Act & Assert - Unknown versions fail the entry
+	_, _, err = registry.MigrateJSONArray([]byte(`[{"version": "1999-01-01"}]`))
+	var entryErr *policy.PolicyEntryError
+	if !errors.As(err, &entryErr) || entryErr.Index != 0 {
+		t.Errorf("Expected an entry error for index 0, got %v", err)
+	}
+}
+
+func TestValidateRejectsUnsupportedVersion(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	p := policyFactory.CreatePolicy("policy-1", "Old",
+		policyFactory.CreateStatement("s1", policy.Allow, []policy.Action{"read"}, []policy.Resource{"doc:*"}),
+	)
+	p.Version = "2022-01-01"
+
+	// Act
+	errs := validator.NewDefaultValidator().Validate(p)
+
+	// Assert
+	if len(errs) != 1 || errs[0].Code != validator.CodeUnsupportedVersion {
+		t.Errorf("Expected an unsupported version error, got %v", errs)
+	}
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+)
+
+type accountID uint32
+
+func TestNumericEvaluatorExactIntegers(t *testing.T) {
+	// Arrange
+	numericEvaluator := condition.NewNumericEvaluator()
+
+	// Act & Assert - 2^53 + 1 is not representable as float64
+	if numericEvaluator.Equals(int64(9007199254740993), int64(9007199254740992)) {
+		t.Errorf("Distinct large int64 values should not be equal")
+	}
+
+	if !numericEvaluator.GreaterThan(uint64(18446744073709551615), int64(9223372036854775807)) {
+		t.Errorf("Max uint64 should be greater than max int64")
+	}
+
+	if !numericEvaluator.LessThan(int64(-1), uint64(0)) {
+		t.Errorf("Negative int64 should be less than uint64 zero")
+	}
+
+	if !numericEvaluator.Equals(int32(42), uint8(42)) || !numericEvaluator.Equals(accountID(7), 7) {
+		t.Errorf("Integers of different kinds should compare by value")
+	}
+
+	if !numericEvaluator.Equals(json.Number("9007199254740993"), "9007199254740993") {
+		t.Errorf("json.Number should compare exactly against a numeric string")
+	}
+}
+
+func TestNumericEvaluatorDecimals(t *testing.T) {
+	// Arrange
+	numericEvaluator := condition.NewNumericEvaluator()
+
+	// Act & Assert
+	if !numericEvaluator.GreaterThan("1000000000000.01", "1000000000000.001") {
+		t.Errorf("Decimal amounts should be compared with arbitrary precision")
+	}
+
+	if numericEvaluator.Equals("0.30000000000000001", "0.3") {
+		t.Errorf("Decimal strings differing beyond float64 precision should not be equal")
+	}
+
+	if !numericEvaluator.Equals(0.1, "0.1") || !numericEvaluator.Equals(float32(0.1), "0.1") {
+		t.Errorf("Floats should compare by their shortest decimal representation")
+	}
+
+	if !numericEvaluator.LessThanEquals(2, 2.0) || !numericEvaluator.Equals("1e3", 1000) {
+		t.Errorf("Integers and floats with the same value should be equal")
+	}
+
+	if numericEvaluator.Equals("1/3", "1/3") || numericEvaluator.Equals("NaN", "NaN") {
+		t.Errorf("Non-decimal strings should not be accepted as numbers")
+	}
+}
+
+func TestConditionJSONKeepsNumericPrecision(t *testing.T) {
+	// Arrange
+	jsonStr := `{"operator": "NumericEquals", "key": "account.id", "value": 9007199254740993}`
+
+	// Act
+	var result policy.Condition
+	err := json.Unmarshal([]byte(jsonStr), &result)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to deserialize condition: %v", err)
+	}
+
+	if !condition.NewNumericEvaluator().Equals(int64(9007199254740993), result.Value) {
+		t.Errorf("Condition value should keep its integer precision, got %v (%T)", result.Value, result.Value)
+	}
+
+	if condition.NewNumericEvaluator().Equals(int64(9007199254740992), result.Value) {
+		t.Errorf("Condition value should not be rounded through float64")
+	}
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+func TestGlobPatternMatcher(t *testing.T) {
+	// Arrange
+	matcher := condition.NewGlobPatternMatcher("/")
+	cases := []struct {
+		input   string
+		pattern string
+		matches bool
+	}{
+		{"org:acme/project:42", "org:acme/*", true},
+		{"org:acme/project:42/doc:7", "org:acme/*", false},
+		{"org:acme/project:42/doc:7", "org:acme/**", true},
+		{"org:acme/doc:7", "org:acme/**/doc:7", true},
+		{"org:acme/a/b/doc:7", "org:acme/**/doc:7", true},
+		{"doc:7", "**/doc:7", true},
+		{"file1.txt", "file?.txt", true},
+		{"file10.txt", "file?.txt", false},
+		{"doc-b", "doc-[a-c]", true},
+		{"doc-d", "doc-[a-c]", false},
+		{"doc-d", "doc-[!a-c]", true},
+		{"s3:GetObject", "s3:{Get,Put}Object", true},
+		{"s3:DeleteObject", "s3:{Get,Put}Object", false},
+		{"img.jpeg", "img.{jp{e,}g,png}", true},
+		{"img.jpg", "img.{jp{e,}g,png}", true},
+		{"a*b", `a\*b`, true},
+		{"axb", `a\*b`, false},
+		{"café/menu", "caf?/*", true},
+	}
+
+	// Act & Assert
+	for _, c := range cases {
+		if matcher.MatchesPattern(c.input, c.pattern) != c.matches {
+			t.Errorf("MatchesPattern(%q, %q) should be %v", c.input, c.pattern, c.matches)
+		}
+	}
+}
+
+func TestExtendedGlobConditionFactory(t *testing.T) {
+	// Arrange
+	conditionFactory := factory.NewConditionFactory()
+	conditionFactory.ExtendedGlob = true
+	evaluatorFactory := factory.NewEvaluatorFactoryWithConditionFactory(conditionFactory)
+	policyFactory := factory.NewPolicyFactory()
+	eval := evaluatorFactory.CreatePolicyEvaluator(policyFactory.CreatePolicy(
+		"policy-1",
+		"Documents",
+		policyFactory.CreateStatement(
+			"statement-1",
+			policy.Allow,
+			[]policy.Action{"doc:{read,list}"},
+			[]policy.Resource{"org:acme/*"},
+		),
+	))
+
+	// Act & Assert
+	if !eval.Evaluate(evaluator.Request{Action: "doc:read", Resource: "org:acme/project:42"}).Allowed {
+		t.Errorf("Request within a single segment should be allowed")
+	}
+
+	if eval.Evaluate(evaluator.Request{Action: "doc:read", Resource: "org:acme/project:42/doc:7"}).Allowed {
+		t.Errorf("Single-segment wildcard should not cross segment boundaries")
+	}
+
+	if eval.Evaluate(evaluator.Request{Action: "doc:delete", Resource: "org:acme/project:42"}).Allowed {
+		t.Errorf("Action outside the alternation should not be allowed")
+	}
+}
+
+func TestRegexConditionOperators(t *testing.T) {
+	// Arrange
+	compositeEvaluator := condition.NewCompositeEvaluator()
+	context := map[string]interface{}{"user.email": "alice@example.com"}
+
+	// Act & Assert
+	if !compositeEvaluator.Evaluate(policy.Condition{
+		Operator: policy.StringMatchesRegex,
+		Key:      "user.email",
+		Value:    `^[a-z]+@example\.com$`,
+	}, context) {
+		t.Errorf("Email should match the regular expression")
+	}
+
+	if compositeEvaluator.Evaluate(policy.Condition{
+		Operator: policy.StringNotMatchesRegex,
+		Key:      "user.email",
+		Value:    `@example\.com$`,
+	}, context) {
+		t.Errorf("StringNotMatchesRegex should not match a matching email")
+	}
+
+	if compositeEvaluator.Evaluate(policy.Condition{
+		Operator: policy.StringNotMatchesRegex,
+		Key:      "user.email",
+		Value:    `(unclosed`,
+	}, context) {
+		t.Errorf("Invalid regular expressions should never match")
+	}
+}
+
+func TestValidateRegexConditionValues(t *testing.T) {
+	// Arrange
+	conditionValidator := validator.NewConditionValidator()
+
+	// Act
+	invalidErrs := conditionValidator.ValidateCondition(policy.Condition{
+		Operator: policy.StringMatchesRegex,
+		Key:      "user.email",
+		Value:    `(unclosed`,
+	}, 0, 0)
+	tooLongErrs := conditionValidator.ValidateCondition(policy.Condition{
+		Operator: policy.StringMatchesRegex,
+		Key:      "user.email",
+		Value:    strings.Repeat("a", condition.MaxRegexLength+1),
+	}, 0, 0)
+
+	// Assert
+	if len(invalidErrs) == 0 {
+		t.Errorf("Should return an error for an invalid regular expression")
+	}
+
+	if len(tooLongErrs) == 0 {
+		t.Errorf("Should return an error for a regular expression exceeding the length limit")
+	}
+}
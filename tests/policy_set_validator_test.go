@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/coverage"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+func TestValidatePolicySet(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	setValidator := factory.NewValidatorFactory().CreatePolicySetValidator()
+	policies := []policy.Policy{
+		policyFactory.CreatePolicy("policy-1", "Readers",
+			policyFactory.CreateStatement("allow-read", policy.Allow,
+				[]policy.Action{"read"}, []policy.Resource{"document:report"}),
+		),
+		policyFactory.CreatePolicy("policy-2", "Lockdown",
+			policyFactory.CreateStatement("deny-documents", policy.Deny,
+				[]policy.Action{"*"}, []policy.Resource{"document:*"}),
+		),
+		policyFactory.CreatePolicy("policy-1", "Writers",
+			policyFactory.CreateStatement("allow-read", policy.Allow,
+				[]policy.Action{"write"}, []policy.Resource{"file:*"}),
+		),
+	}
+
+	// Act
+	errs := setValidator.ValidateSet(policies)
+
+	// Assert
+	expected := map[string]validator.ErrorCode{
+		"/policies/2/id":              validator.CodeDuplicatePolicyID,
+		"/policies/2/statements/0/id": validator.CodeDuplicateStatementID,
+		"/policies/0/statements/0":    validator.CodeConflictingStatements,
+	}
+	if len(errs) != len(expected) {
+		t.Errorf("Expected %d errors, got %v", len(expected), errs)
+	}
+	for _, err := range errs {
+		if expected[err.Pointer] != err.Code {
+			t.Errorf("Unexpected error %s at %s: %v", err.Code, err.Pointer, err)
+		}
+		if err.PolicyID == "" {
+			t.Errorf("Set-level errors should carry the policy ID: %v", err)
+		}
+	}
+
+	// Act & Assert - Per-policy errors are reported with set-level pointers
+	policies[1].Statements[0].Effect = "Maybe"
+	setValidator.MaxPolicies = 2
+	errs = setValidator.ValidateSet(policies)
+	found := map[string]bool{}
+	for _, err := range errs {
+		found[err.Pointer] = true
+	}
+	if !found["/policies/1/statements/0/effect"] || !found["/policies"] {
+		t.Errorf("Expected the invalid effect and the policy quota errors, got %v", errs)
+	}
+}
+
+func TestValidatePolicySetConflictsFollowCoverage(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	policies := []policy.Policy{
+		policyFactory.CreatePolicy("policy-1", "Readers",
+			policyFactory.CreateStatement("allow-nested", policy.Allow,
+				[]policy.Action{"docs:read/all"}, []policy.Resource{"document:report"}),
+		),
+		policyFactory.CreatePolicy("policy-2", "Lockdown",
+			policyFactory.CreateStatement("deny-docs", policy.Deny,
+				[]policy.Action{"docs:*"}, []policy.Resource{"document:*"}),
+		),
+	}
+	conditionFactory := factory.NewConditionFactory()
+	conditionFactory.ExtendedGlob = true
+	globValidator := validator.NewPolicySetValidatorWithCoverage(nil,
+		coverage.NewCoverageFromProvider(factory.NewConditionFactoryAdapter(conditionFactory)))
+	defaultValidator := validator.NewPolicySetValidator(nil)
+
+	// Act
+	globErrs := globValidator.ValidateSet(policies)
+	defaultErrs := defaultValidator.ValidateSet(policies)
+
+	// Assert
+	if len(globErrs) != 0 {
+		t.Errorf("Expected no conflict when * does not cross separators: %v", globErrs)
+	}
+	if len(defaultErrs) != 1 || defaultErrs[0].Code != validator.CodeConflictingStatements {
+		t.Errorf("Expected the default coverage to report the conflict: %v", defaultErrs)
+	}
+}
@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/convert"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/rego"
+)
+
+func TestRegoExport(t *testing.T) {
+	// Arrange
+	policies := []policy.Policy{{
+		ID: "docs",
+		Statements: []policy.Statement{
+			{
+				ID:         "read",
+				Effect:     policy.Allow,
+				Actions:    []policy.Action{"docs:read"},
+				Resources:  []policy.Resource{"docs/**"},
+				Conditions: []policy.Condition{{Operator: policy.StringEquals, Key: "team", Value: "eng"}},
+			},
+			{
+				ID:        "no-secrets",
+				Effect:    policy.Deny,
+				Actions:   []policy.Action{"*"},
+				Resources: []policy.Resource{"docs/secret"},
+			},
+		},
+	}}
+
+	// Act
+	module, err := rego.Export(policies, rego.Options{Package: "policies.docs"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	expected := []string{
+		"package policies.docs\n",
+		"default allow := false\n",
+		"allow if {\n\tallowed\n\tnot deny\n}\n",
+		"# policy \"docs\", statement \"read\"\nallowed if {\n\tregex.match(\"^(?:docs:read)$\", input.action)\n" +
+			"\tregex.match(\"^(?:/docs(?:/[^/]*)*)$\", resource_path)\n\tstring_value(\"team\") == \"eng\"\n}\n",
+		"# policy \"docs\", statement \"no-secrets\"\ndeny if {\n\tregex.match(\"^(?:.*)$\", input.action)\n",
+		"resource_path := concat(\"\", [\"/\", input.resource])\n",
+	}
+	for _, fragment := range expected {
+		if !strings.Contains(module, fragment) {
+			t.Errorf("Expected module to contain %q, got:\n%s", fragment, module)
+		}
+	}
+}
+
+func TestRegoExportUnsupported(t *testing.T) {
+	// Arrange
+	policies := []policy.Policy{{
+		ID: "mixed",
+		Statements: []policy.Statement{
+			{
+				Effect:     policy.Allow,
+				Actions:    []policy.Action{"deploy"},
+				Resources:  []policy.Resource{"*"},
+				Conditions: []policy.Condition{{Operator: policy.VersionGreaterThan, Key: "client", Value: "1.2.0"}},
+			},
+			{
+				Effect:     policy.Allow,
+				Actions:    []policy.Action{"read"},
+				Resources:  []policy.Resource{"*"},
+				Conditions: []policy.Condition{{Operator: policy.DateGreaterThan, Key: "time", Value: "now-1h"}},
+			},
+			{Effect: policy.Allow, Actions: []policy.Action{"list"}, Resources: []policy.Resource{"*"}},
+		},
+	}}
+	withDeny := append([]policy.Policy{}, policies...)
+	withDeny[0].Statements = append([]policy.Statement{{
+		Effect:     policy.Deny,
+		Actions:    []policy.Action{"*"},
+		Resources:  []policy.Resource{"*"},
+		Conditions: []policy.Condition{{Operator: policy.ScheduleTimeOfDay, Value: "22:00-06:00"}},
+	}}, policies[0].Statements...)
+
+	// Act
+	_, err := rego.Compile(policies, rego.Options{})
+	skipped, skipErr := rego.Compile(policies, rego.Options{SkipUnsupported: true})
+	_, denyErr := rego.Compile(withDeny, rego.Options{SkipUnsupported: true})
+
+	// Assert
+	var unsupported *convert.ConversionError
+	if !errors.As(err, &unsupported) || len(unsupported.Report.Unsupported()) != 2 {
+		t.Fatalf("Expected two unsupported constructs, got %v", err)
+	}
+	if issues := unsupported.Report.Issues; issues[0].Path != "/policies/0/statements/0/conditions/0/operator" ||
+		issues[1].Path != "/policies/0/statements/1/conditions/0/value" {
+		t.Errorf("Unexpected issue paths: %+v", issues)
+	}
+	if skipErr != nil || len(skipped.Rules) != 1 {
+		t.Errorf("SkipUnsupported should keep only the supported Allow statement, got %v %+v", skipErr, skipped)
+	}
+	if !errors.As(denyErr, &unsupported) {
+		t.Errorf("Unsupported Deny statements must not be skipped, got %v", denyErr)
+	}
+}
+
+// TestRegoConformance compares the decisions of exported modules with the
+// evaluator on randomly generated policies and requests.
+func TestRegoConformance(t *testing.T) {
+	// Arrange
+	random := rand.New(rand.NewSource(42))
+	evaluatorFactory := factory.NewEvaluatorFactory()
+
+	for round := 0; round < 200; round++ {
+		policies := []policy.Policy{randomPolicy(random, "p1"), randomPolicy(random, "p2")}
+		module, err := rego.Compile(policies, rego.Options{})
+		if err != nil {
+			t.Fatalf("Compile failed: %v", err)
+		}
+		engine := evaluatorFactory.CreatePolicyEvaluator(policies...)
+
+		for i := 0; i < 25; i++ {
+			req := randomRequest(random)
+
+			// Act
+			expected := engine.Evaluate(req).Allowed
+			input, err := rego.Input(req)
+			if err != nil {
+				t.Fatalf("Input failed: %v", err)
+			}
+			actual := module.Eval(input)
+
+			// Assert
+			if actual != expected {
+				t.Fatalf("Decision mismatch for %+v: engine %v, rego %v\npolicies: %s\nmodule:\n%s",
+					req, expected, actual, mustJSON(policies), module)
+			}
+		}
+	}
+}
+
+var (
+	conformancePrincipals      = []policy.Principal{"alice", "bob", "svc:*"}
+	conformanceActions         = []policy.Action{"docs:read", "docs:write", "admin:delete", "read"}
+	conformanceActionPatterns  = []policy.Action{"docs:read", "docs:*", "*", "*:delete", "read"}
+	conformanceResources       = []policy.Resource{"docs/a", "docs/a/b", "img/x", "docs", "docs/a.txt"}
+	conformanceResourcePattern = []policy.Resource{"*", "docs/*", "docs/**", "docs/a", "*/x", "docs/**/b", "docs/a*"}
+)
+
+func randomPolicy(random *rand.Rand, id string) policy.Policy {
+	p := policy.Policy{ID: id}
+	for i := 0; i < 1+random.Intn(3); i++ {
+		statement := policy.Statement{
+			ID:        fmt.Sprintf("%s-s%d", id, i),
+			Effect:    policy.Allow,
+			Actions:   []policy.Action{conformanceActionPatterns[random.Intn(len(conformanceActionPatterns))]},
+			Resources: []policy.Resource{conformanceResourcePattern[random.Intn(len(conformanceResourcePattern))]},
+		}
+		if random.Intn(4) == 0 {
+			statement.Effect = policy.Deny
+		}
+		if random.Intn(3) == 0 {
+			statement.Principals = []policy.Principal{conformancePrincipals[random.Intn(len(conformancePrincipals))]}
+		}
+		for j := 0; j < random.Intn(3); j++ {
+			statement.Conditions = append(statement.Conditions, randomCondition(random))
+		}
+		p.Statements = append(p.Statements, statement)
+	}
+	return p
+}
+
+func randomCondition(random *rand.Rand) policy.Condition {
+	switch random.Intn(4) {
+	case 0:
+		operators := []policy.ConditionOperator{
+			policy.StringEquals, policy.StringNotEquals, policy.StringEqualsIgnoreCase,
+			policy.StringNotEqualsIgnoreCase, policy.StringLike, policy.StringNotLike,
+			policy.StringMatchesRegex, policy.StringNotMatchesRegex,
+		}
+		values := []string{"admin", "Admin", "adm*", "^ad", "guest"}
+		return policy.Condition{Operator: operators[random.Intn(len(operators))], Key: "role", Value: values[random.Intn(len(values))]}
+	case 1:
+		operators := []policy.ConditionOperator{
+			policy.NumericEquals, policy.NumericNotEquals, policy.NumericLessThan,
+			policy.NumericLessThanEquals, policy.NumericGreaterThan, policy.NumericGreaterThanEquals,
+		}
+		values := []interface{}{json.Number("5"), 2.5, "10", 0}
+		return policy.Condition{Operator: operators[random.Intn(len(operators))], Key: "level", Value: values[random.Intn(len(values))]}
+	case 2:
+		operators := []policy.ConditionOperator{
+			policy.DateEquals, policy.DateNotEquals, policy.DateLessThan,
+			policy.DateLessThanEquals, policy.DateGreaterThan, policy.DateGreaterThanEquals,
+		}
+		values := []interface{}{"2024-01-01T00:00:00Z", "2024-06-01", json.Number("1704067200")}
+		return policy.Condition{Operator: operators[random.Intn(len(operators))], Key: "created", Value: values[random.Intn(len(values))]}
+	}
+	return policy.Condition{Operator: policy.Bool, Key: "mfa", Value: random.Intn(2) == 0}
+}
+
+func randomRequest(random *rand.Rand) evaluator.Request {
+	candidates := map[string][]interface{}{
+		"role":    {"admin", "ADMIN", "administrator", "guest", 7},
+		"level":   {5, 2.5, "10", "abc", json.Number("7"), true},
+		"created": {"2024-01-01T00:00:00Z", "2024-06-01", 1704067200, 1717200000000, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), "soon"},
+		"mfa":     {true, false, "true"},
+	}
+	context := map[string]interface{}{}
+	for key, values := range candidates {
+		if random.Intn(3) > 0 {
+			context[key] = values[random.Intn(len(values))]
+		}
+	}
+	principals := []string{"alice", "bob", "svc:ci", ""}
+	return evaluator.Request{
+		Principal: principals[random.Intn(len(principals))],
+		Action:    conformanceActions[random.Intn(len(conformanceActions))],
+		Resource:  conformanceResources[random.Intn(len(conformanceResources))],
+		Context:   context,
+	}
+}
+
+func mustJSON(value interface{}) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+)
+
+func TestParseResourceName(t *testing.T) {
+	// Arrange
+	name := policy.ParseResourceName("org:acme/project:42/doc:7", policy.DefaultResourceNameSyntax)
+
+	// Assert
+	if len(name.Segments) != 3 {
+		t.Fatalf("Incorrect number of segments: expected 3, got %d", len(name.Segments))
+	}
+
+	if name.Segments[1].Type != "project" || name.Segments[1].ID != "42" {
+		t.Errorf("Incorrect second segment: %+v", name.Segments[1])
+	}
+
+	if name.Type() != "doc" || name.String() != "org:acme/project:42/doc:7" {
+		t.Errorf("Incorrect type or string form: %s, %s", name.Type(), name.String())
+	}
+
+	parent, ok := name.Parent()
+	if !ok || parent.String() != "org:acme/project:42" {
+		t.Errorf("Incorrect parent: %s", parent.String())
+	}
+
+	if !parent.IsAncestorOf(name) || name.IsAncestorOf(parent) {
+		t.Errorf("Parent should be an ancestor of the resource and not the other way around")
+	}
+
+	if len(name.Ancestors()) != 2 {
+		t.Errorf("Incorrect number of ancestors: expected 2, got %d", len(name.Ancestors()))
+	}
+}
+
+func TestHierarchicalResourceMatcher(t *testing.T) {
+	// Arrange
+	matcher := condition.NewHierarchicalResourceMatcher(condition.NewRegexPatternMatcher(), "/", false)
+	cases := []struct {
+		resource string
+		pattern  string
+		matches  bool
+	}{
+		{"org:acme/project:42", "org:acme/*", true},
+		{"org:acme/project:42/doc:7", "org:acme/*", false},
+		{"org:acme/project:42/doc:7", "org:acme/**", true},
+		{"org:acme/project:42/doc:7", "org:acme/**/doc:*", true},
+		{"org:acme/doc:7", "org:acme/**/doc:*", true},
+		{"org:acme/project:42/doc:7", "org:*/project:42/doc:7", true},
+		{"org:acme-labs/project:1", "org:acme*/project:1", true},
+		{"org:acme/project:42", "org:acme", false},
+		{"org:acme/project:42", "*", true},
+		{"org:acme/doc:x:7", "org:acme/doc*:7", false},
+		{"org:acme/doc:7", "org:acme/d*:7", true},
+		{"org:acme/doc:7", "org:acme/doc:*", true},
+		{"org:acme/doc", "org:acme/doc:*", false},
+	}
+
+	// Act & Assert
+	for _, c := range cases {
+		if matcher.MatchesResource(c.resource, c.pattern) != c.matches {
+			t.Errorf("MatchesResource(%q, %q) should be %v", c.resource, c.pattern, c.matches)
+		}
+	}
+}
+
+func TestParentPermissionsApplyToDescendants(t *testing.T) {
+	// Arrange
+	conditionFactory := factory.NewConditionFactory()
+	conditionFactory.IncludeDescendants = true
+	evaluatorFactory := factory.NewEvaluatorFactoryWithConditionFactory(conditionFactory)
+	policyFactory := factory.NewPolicyFactory()
+	eval := evaluatorFactory.CreatePolicyEvaluator(policyFactory.CreatePolicy(
+		"policy-1",
+		"Project Access",
+		policyFactory.CreateStatement(
+			"statement-1",
+			policy.Allow,
+			[]policy.Action{"read"},
+			[]policy.Resource{"org:acme/project:42"},
+		),
+	))
+
+	// Act & Assert
+	if !eval.Evaluate(evaluator.Request{Action: "read", Resource: "org:acme/project:42/doc:7"}).Allowed {
+		t.Errorf("Permission on the project should apply to its documents")
+	}
+
+	if !eval.Evaluate(evaluator.Request{Action: "read", Resource: "org:acme/project:42"}).Allowed {
+		t.Errorf("Permission should apply to the project itself")
+	}
+
+	if eval.Evaluate(evaluator.Request{Action: "read", Resource: "org:acme/project:420"}).Allowed {
+		t.Errorf("Permission should not apply to sibling resources sharing a prefix")
+	}
+
+	if eval.Evaluate(evaluator.Request{Action: "read", Resource: "org:acme"}).Allowed {
+		t.Errorf("Permission should not apply to the parent resource")
+	}
+}
@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+)
+
+func TestScheduleConditionsUseInjectedClock(t *testing.T) {
+	// Arrange - Monday 2023-05-01 13:30 UTC is 10:30 in America/Sao_Paulo
+	clock := condition.NewFixedClock(time.Date(2023, 5, 1, 13, 30, 0, 0, time.UTC))
+	evaluatorFactory := factory.NewEvaluatorFactoryWithConditionFactory(
+		factory.NewConditionFactoryWithClock(clock),
+	)
+	policyFactory := factory.NewPolicyFactory()
+	officeHours := policyFactory.CreatePolicy(
+		"policy-1",
+		"Office Hours",
+		policyFactory.CreateStatement(
+			"statement-1",
+			policy.Allow,
+			[]policy.Action{"read"},
+			[]policy.Resource{"resource:*"},
+		),
+	)
+	officeHours.Statements[0].Conditions = []policy.Condition{
+		{
+			Operator: policy.ScheduleTimeOfDay,
+			Value: map[string]interface{}{
+				"start":    "09:00",
+				"end":      "18:00",
+				"timezone": "America/Sao_Paulo",
+			},
+		},
+		{
+			Operator: policy.ScheduleDayOfWeek,
+			Value:    "TZ=America/Sao_Paulo Mon-Fri",
+		},
+	}
+	eval := evaluatorFactory.CreatePolicyEvaluator(officeHours)
+	req := evaluator.Request{Principal: "user-1", Action: "read", Resource: "resource:doc1"}
+
+	// Act & Assert - Within office hours
+	if !eval.Evaluate(req).Allowed {
+		t.Errorf("Request on Monday 10:30 in Sao Paulo should be allowed")
+	}
+
+	// Act & Assert - After hours (19:00 in Sao Paulo)
+	clock.Set(time.Date(2023, 5, 1, 22, 0, 0, 0, time.UTC))
+	if eval.Evaluate(req).Allowed {
+		t.Errorf("Request on Monday 19:00 in Sao Paulo should be denied")
+	}
+
+	// Act & Assert - Saturday within hours
+	clock.Set(time.Date(2023, 5, 6, 13, 30, 0, 0, time.UTC))
+	if eval.Evaluate(req).Allowed {
+		t.Errorf("Request on Saturday should be denied")
+	}
+}
+
+func TestScheduleConditionWithContextKey(t *testing.T) {
+	// Arrange
+	compositeEvaluator := condition.NewCompositeEvaluator()
+	cond := policy.Condition{
+		Operator: policy.ScheduleTimeOfDay,
+		Key:      "request.time",
+		Value:    "22:00-06:00",
+	}
+
+	// Act & Assert - Overnight range wraps around midnight
+	if !compositeEvaluator.Evaluate(cond, map[string]interface{}{"request.time": "2023-05-01T23:15:00Z"}) {
+		t.Errorf("23:15 should be within 22:00-06:00")
+	}
+
+	if compositeEvaluator.Evaluate(cond, map[string]interface{}{"request.time": "2023-05-01T12:00:00Z"}) {
+		t.Errorf("12:00 should not be within 22:00-06:00")
+	}
+
+	// Act & Assert - A named key missing from the context does not fall back to now
+	if compositeEvaluator.Evaluate(cond, map[string]interface{}{}) {
+		t.Errorf("Missing context key should not match")
+	}
+}
+
+func TestCronSchedule(t *testing.T) {
+	// Arrange
+	schedule, err := condition.ParseCronSchedule("*/15 9-17 * * MON-FRI")
+	if err != nil {
+		t.Fatalf("Failed to parse cron expression: %v", err)
+	}
+
+	// Act & Assert
+	cases := []struct {
+		at      time.Time
+		matches bool
+	}{
+		{time.Date(2023, 5, 1, 9, 0, 0, 0, time.UTC), true},
+		{time.Date(2023, 5, 1, 9, 15, 30, 0, time.UTC), true},
+		{time.Date(2023, 5, 1, 9, 16, 0, 0, time.UTC), false},
+		{time.Date(2023, 5, 1, 18, 0, 0, 0, time.UTC), false},
+		{time.Date(2023, 5, 7, 10, 0, 0, 0, time.UTC), false},
+	}
+	for _, c := range cases {
+		if schedule.Matches(c.at) != c.matches {
+			t.Errorf("Incorrect match for %v: expected %v", c.at, c.matches)
+		}
+	}
+
+	// Act & Assert - Invalid expressions are rejected
+	if _, err := condition.ParseCronSchedule("61 * * * *"); err == nil {
+		t.Errorf("Should reject out-of-range minute")
+	}
+
+	if _, err := condition.ParseCronSchedule("TZ=Mars/Olympus 0 9 * * *"); err == nil {
+		t.Errorf("Should reject unknown time zone")
+	}
+}
+
+func TestCronScheduleDayFields(t *testing.T) {
+	// Arrange - 2023-05-02 is an even Tuesday and 2023-05-08 an even Monday
+	everyDayOrMonday, errFull := condition.ParseCronSchedule("0 9 1-31 * MON")
+	oddDaysOrMonday, errOdd := condition.ParseCronSchedule("0 9 */2 * MON")
+	if errFull != nil || errOdd != nil {
+		t.Fatalf("Failed to parse cron expressions: %v %v", errFull, errOdd)
+	}
+	tuesday := time.Date(2023, 5, 2, 9, 0, 0, 0, time.UTC)
+	monday := time.Date(2023, 5, 8, 9, 0, 0, 0, time.UTC)
+
+	// Act & Assert - A day-of-month field covering every day does not restrict
+	if everyDayOrMonday.Matches(tuesday) || !everyDayOrMonday.Matches(monday) {
+		t.Errorf("1-31 should select Mondays only")
+	}
+
+	// Act & Assert - Restricted fields select a day matching either one
+	if oddDaysOrMonday.Matches(tuesday) || !oddDaysOrMonday.Matches(monday) || !oddDaysOrMonday.Matches(monday.AddDate(0, 0, 1)) {
+		t.Errorf("*/2 and MON should select odd days and Mondays")
+	}
+}
+
+func TestScheduleConditionValuesAreCachedSeparately(t *testing.T) {
+	// Arrange - 2023-05-01 23:30 UTC is still Monday in Sao Paulo but Tuesday in Tokyo
+	compositeEvaluator := condition.NewCompositeEvaluator()
+	context := map[string]interface{}{"at": "2023-05-01T23:30:00Z"}
+	monday := func(timezone string) policy.Condition {
+		return policy.Condition{
+			Operator: policy.ScheduleDayOfWeek,
+			Key:      "at",
+			Value:    map[string]interface{}{"days": "Mon", "timezone": timezone},
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		// Act
+		saoPaulo := compositeEvaluator.Evaluate(monday("America/Sao_Paulo"), context)
+		tokyo := compositeEvaluator.Evaluate(monday("Asia/Tokyo"), context)
+
+		// Assert
+		if !saoPaulo || tokyo {
+			t.Errorf("Expected Monday in Sao Paulo only, got Sao Paulo %v and Tokyo %v", saoPaulo, tokyo)
+		}
+	}
+}
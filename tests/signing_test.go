@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/signing"
+)
+
+func signingPolicy() policy.Policy {
+	return policy.Policy{
+		Version:   policy.PolicyVersion,
+		ID:        "docs",
+		Name:      "Docs",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Statements: []policy.Statement{{
+			Effect:    policy.Allow,
+			Actions:   []policy.Action{"docs:read"},
+			Resources: []policy.Resource{"docs/*"},
+		}},
+	}
+}
+
+func signingKeys(t *testing.T) (map[string]crypto.Signer, *signing.Keyring) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	keys := map[string]crypto.Signer{"ed": edKey, "ec": ecKey}
+	keyring := signing.NewKeyring()
+	for kid, key := range keys {
+		if err := keyring.Add(kid, key.Public()); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	return keys, keyring
+}
+
+func TestDetachedSignature(t *testing.T) {
+	// Arrange
+	keys, keyring := signingKeys(t)
+	p := signingPolicy()
+	tampered := signingPolicy()
+	tampered.Statements[0].Resources = []policy.Resource{"*"}
+
+	for kid, key := range keys {
+		// Act
+		jws, err := signing.Sign(p, kid, key)
+		if err != nil {
+			t.Fatalf("Sign with %s failed: %v", kid, err)
+		}
+		validErr := signing.Verify(p, jws, keyring)
+		tamperedErr := signing.Verify(tampered, jws, keyring)
+
+		// Assert
+		if validErr != nil {
+			t.Errorf("Expected %s signature to verify, got %v", kid, validErr)
+		}
+		if !errors.Is(tamperedErr, signing.ErrInvalidSignature) {
+			t.Errorf("Expected tampered policy to fail with %s, got %v", kid, tamperedErr)
+		}
+	}
+}
+
+// opaqueSigner hides the concrete key type, like signers backed by an HSM or
+// KMS, and records the hash it was asked to sign with.
+type opaqueSigner struct {
+	key  crypto.Signer
+	hash crypto.Hash
+}
+
+func (s *opaqueSigner) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+func (s *opaqueSigner) Sign(random io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	s.hash = opts.HashFunc()
+	return s.key.Sign(random, digest, opts)
+}
+
+func TestDetachedSignatureWithOpaqueSigner(t *testing.T) {
+	// Arrange
+	keys, keyring := signingKeys(t)
+	signer := &opaqueSigner{key: keys["ec"]}
+	p := signingPolicy()
+
+	// Act
+	jws, err := signing.Sign(p, "ec", signer)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Sign with a wrapped ECDSA key failed: %v", err)
+	}
+	if signer.hash != crypto.SHA256 {
+		t.Errorf("Expected the signer to be asked for a SHA-256 signature, got %v", signer.hash)
+	}
+	if err := signing.Verify(p, jws, keyring); err != nil {
+		t.Errorf("Expected the wrapped signer's signature to verify, got %v", err)
+	}
+}
+
+func TestEnvelopeOpen(t *testing.T) {
+	// Arrange
+	keys, keyring := signingKeys(t)
+	p := signingPolicy()
+	envelope, err := signing.Seal(p, "ed", keys["ed"])
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+	if err := envelope.AddSignature("ec", keys["ec"]); err != nil {
+		t.Fatalf("AddSignature failed: %v", err)
+	}
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	untrusted, _ := signing.Seal(p, "other", otherKey)
+	forged := envelope
+	forged.Signatures = []signing.Signature{envelope.Signatures[0]}
+	forged.Signatures[0].Signature = envelope.Signatures[1].Signature
+
+	// Act
+	opened, openErr := envelope.Open(keyring)
+	_, untrustedErr := untrusted.Open(keyring)
+	_, forgedErr := forged.Open(keyring)
+
+	// Assert
+	if openErr != nil {
+		t.Fatalf("Open failed: %v", openErr)
+	}
+	expected, _ := p.Hash()
+	if actual, _ := opened.Hash(); actual != expected {
+		t.Errorf("Expected opened policy to equal the signed one")
+	}
+	if !errors.Is(untrustedErr, signing.ErrUnknownKey) {
+		t.Errorf("Expected ErrUnknownKey, got %v", untrustedErr)
+	}
+	if !errors.Is(forgedErr, signing.ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", forgedErr)
+	}
+}
+
+func TestLoaderRefusesUnsignedPolicies(t *testing.T) {
+	// Arrange
+	keys, keyring := signingKeys(t)
+	envelope, _ := signing.Seal(signingPolicy(), "ec", keys["ec"])
+	plain := signingPolicy()
+	plain.ID = "plain"
+	data, _ := json.Marshal([]interface{}{envelope, plain})
+
+	// Act
+	_, strictErr := signing.NewLoader(keyring).Load(data)
+	engine, err := signing.NewLoader(keyring, signing.AllowUnsigned()).LoadEvaluator(data, factory.NewEvaluatorFactory())
+
+	// Assert
+	if !errors.Is(strictErr, signing.ErrUnsigned) || strictErr.Error() != "policy 1: policy is not signed" {
+		t.Errorf("Expected the unsigned entry to be refused, got %v", strictErr)
+	}
+	if err != nil {
+		t.Fatalf("LoadEvaluator failed: %v", err)
+	}
+	if !engine.Evaluate(evaluator.Request{Action: "docs:read", Resource: "docs/a"}).Allowed {
+		t.Errorf("Expected loaded policies to allow docs:read")
+	}
+}
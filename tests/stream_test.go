@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+const streamPolicy = `{"version":"2023-01-01","id":"%s","name":"N","created_at":"2024-01-01T00:00:00Z",
+	"statements":[{"effect":"Allow","actions":["read"],"resources":["*"]}]}`
+
+func streamEntry(id string) string {
+	return strings.Replace(streamPolicy, "%s", id, 1)
+}
+
+func TestPolicyEncoderDecoderRoundTrip(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	encoder := policy.NewJSONListEncoder(&buf)
+	for _, id := range []string{"a", "b", "c"} {
+		p, _ := policy.FromJSON(streamEntry(id))
+		if err := encoder.Encode(p); err != nil {
+			t.Fatalf("Encode failed: %v", err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Act
+	var ids []string
+	err := policy.NewJSONListDecoder(&buf).Each(func(index int, p policy.Policy) error {
+		ids = append(ids, p.ID)
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Each failed: %v", err)
+	}
+	if strings.Join(ids, ",") != "a,b,c" {
+		t.Errorf("Expected policies a,b,c, got %v", ids)
+	}
+}
+
+func TestPolicyDecoderReportsInvalidEntries(t *testing.T) {
+	// Arrange
+	data := `{"meta":{"tenant":"x"},"policies":[` + streamEntry("a") + `,
+		{"version":"2023-01-01","id":"bad","name":"N","created_at":"2024-01-01T00:00:00Z","statements":[{"effect":"Maybe","actions":["read"],"resources":["*"]}]},
+		{"id":7},` + streamEntry("c") + `],"count":4}`
+	validate := validator.StreamValidation(validator.NewDefaultValidator())
+
+	// Act
+	decoder := policy.NewJSONListDecoder(strings.NewReader(data), policy.WithStreamValidation(validate))
+	first, firstErr := decoder.Next()
+	_, secondErr := decoder.Next()
+	_, thirdErr := decoder.Next()
+	fourth, fourthErr := decoder.Next()
+	_, endErr := decoder.Next()
+
+	var reported []int
+	var ids []string
+	eachErr := policy.NewJSONListDecoder(strings.NewReader(data),
+		policy.WithStreamValidation(validate),
+		policy.ContinueOnError(func(err *policy.PolicyEntryError) { reported = append(reported, err.Index) }),
+	).Each(func(index int, p policy.Policy) error {
+		ids = append(ids, p.ID)
+		return nil
+	})
+
+	// Assert
+	if firstErr != nil || first.ID != "a" || fourthErr != nil || fourth.ID != "c" {
+		t.Errorf("Expected valid entries a and c, got %v %v", firstErr, fourthErr)
+	}
+	var entryErr *policy.PolicyEntryError
+	var validationErrs validator.ValidationErrors
+	if !errors.As(secondErr, &entryErr) || entryErr.Index != 1 || !errors.As(secondErr, &validationErrs) {
+		t.Errorf("Expected validation error for entry 1, got %v", secondErr)
+	}
+	if !errors.As(thirdErr, &entryErr) || entryErr.Index != 2 {
+		t.Errorf("Expected decode error for entry 2, got %v", thirdErr)
+	}
+	if endErr != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", endErr)
+	}
+	if eachErr != nil || strings.Join(ids, ",") != "a,c" || len(reported) != 2 || reported[0] != 1 || reported[1] != 2 {
+		t.Errorf("Expected entries 1 and 2 to be reported and skipped, got %v %v %v", eachErr, ids, reported)
+	}
+}
+
+func TestPolicyDecoderMalformedStream(t *testing.T) {
+	// Arrange
+	data := `[` + streamEntry("a") + `, {"id": ]`
+
+	// Act
+	decoder := policy.NewJSONArrayDecoder(strings.NewReader(data), policy.ContinueOnError(func(*policy.PolicyEntryError) {}))
+	_, firstErr := decoder.Next()
+	_, secondErr := decoder.Next()
+	_, thirdErr := decoder.Next()
+
+	// Assert
+	if firstErr != nil {
+		t.Errorf("Expected first entry to decode, got %v", firstErr)
+	}
+	if secondErr == nil || thirdErr != secondErr {
+		t.Errorf("Expected malformed JSON to abort the stream, got %v then %v", secondErr, thirdErr)
+	}
+}
+
+func TestPolicyEncoderEmpty(t *testing.T) {
+	// Arrange
+	var list, array bytes.Buffer
+
+	// Act
+	policy.NewJSONListEncoder(&list).Close()
+	policy.NewJSONArrayEncoder(&array).Close()
+
+	// Assert
+	if list.String() != `{"policies":[]}` || array.String() != `[]` {
+		t.Errorf("Unexpected empty documents %q and %q", list.String(), array.String())
+	}
+}
+
+func TestPolicyDecoderListEdgeCases(t *testing.T) {
+	// Arrange
+	null := `{"meta": 1, "policies": null}`
+	duplicate := `{"policies": [` + streamEntry("a") + `], "policies": [` + streamEntry("b") + `]}`
+	duplicateAfterNull := `{"policies": null, "policies": []}`
+
+	// Act
+	nullPolicies, nullErr := policy.NewJSONListDecoder(strings.NewReader(null)).Next()
+	var duplicateIDs []string
+	duplicateErr := policy.NewJSONListDecoder(strings.NewReader(duplicate)).Each(func(index int, p policy.Policy) error {
+		duplicateIDs = append(duplicateIDs, p.ID)
+		return nil
+	})
+	_, duplicateAfterNullErr := policy.NewJSONListDecoder(strings.NewReader(duplicateAfterNull)).Next()
+
+	// Assert
+	if nullErr != io.EOF || nullPolicies.ID != "" {
+		t.Errorf("Expected null policies to decode as an empty list, got %v", nullErr)
+	}
+	if duplicateErr == nil || !strings.Contains(duplicateErr.Error(), `duplicate key "policies"`) {
+		t.Errorf("Expected a duplicate policies key to be rejected, got %v after %v", duplicateErr, duplicateIDs)
+	}
+	if duplicateAfterNullErr == nil {
+		t.Errorf("Expected a duplicate policies key after null to be rejected")
+	}
+}
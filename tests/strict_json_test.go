@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+func TestFromJSONStrict(t *testing.T) {
+	// Arrange
+	valid := `{
+  "version": "2023-01-01",
+  "id": "policy-1",
+  "name": "Strict",
+  "statements": [
+    {
+      "effect": "Allow",
+      "actions": ["read"],
+      "resources": ["doc:*"],
+      "conditions": [{"operator": "StringEquals", "key": "user.role", "value": {"nested": [1, 2]}}]
+    }
+  ],
+  "created_at": "2023-05-01T10:00:00Z"
+}`
+
+	// Act
+	result, err := policy.FromJSONStrict(valid)
+
+	// Assert
+	if err != nil || result.ID != "policy-1" || len(result.Statements[0].Conditions) != 1 {
+		t.Fatalf("Valid policy should decode in strict mode: %v %+v", err, result)
+	}
+
+	cases := []struct {
+		name   string
+		json   string
+		path   string
+		line   int
+		column int
+	}{
+		{
+			name:   "misspelled field",
+			json:   "{\n  \"id\": \"p\",\n  \"statements\": [{\"effect\": \"Allow\", \"condtions\": []}]\n}",
+			path:   "$.statements[0].condtions",
+			line:   3,
+			column: 38,
+		},
+		{
+			name:   "duplicate key",
+			json:   `{"id": "p", "id": "q"}`,
+			path:   "$.id",
+			line:   1,
+			column: 13,
+		},
+		{
+			name:   "wrong type",
+			json:   "{\"statements\": [{\"actions\": \"read\"}]}",
+			path:   "$.statements[0].actions",
+			line:   1,
+			column: 29,
+		},
+		{
+			name:   "duplicate key in condition value",
+			json:   `{"statements": [{"conditions": [{"value": {"a": 1, "a": 2}}]}]}`,
+			path:   "$.statements[0].conditions[0].value.a",
+			line:   1,
+			column: 52,
+		},
+		{
+			name:   "unknown schedule field",
+			json:   "{\"statements\": [{\"conditions\": [{\"value\": {\"start\": \"09:00\", \"ned\": \"17:00\"}, \"operator\": \"ScheduleTimeOfDay\"}]}]}",
+			path:   "$.statements[0].conditions[0].value.ned",
+			line:   1,
+			column: 62,
+		},
+		{
+			name:   "invalid timestamp",
+			json:   "{\n  \"created_at\": \"2023-05-01 10:00\"\n}",
+			path:   "$.created_at",
+			line:   2,
+			column: 17,
+		},
+		{
+			name:   "multibyte characters",
+			json:   `{"name": "Política", "idx": 1}`,
+			path:   "$.idx",
+			line:   1,
+			column: 22,
+		},
+		{
+			name:   "trailing data",
+			json:   `{"id": "p"} {}`,
+			path:   "$",
+			line:   1,
+			column: 13,
+		},
+	}
+
+	for _, c := range cases {
+		// Act
+		_, err := policy.FromJSONStrict(c.json)
+
+		// Assert
+		var strictErr *policy.StrictJSONError
+		if !errors.As(err, &strictErr) {
+			t.Errorf("%s: expected a StrictJSONError, got %v", c.name, err)
+			continue
+		}
+		if strictErr.Path != c.path || strictErr.Line != c.line || strictErr.Column != c.column {
+			t.Errorf("%s: expected %s at %d:%d, got %v", c.name, c.path, c.line, c.column, strictErr)
+		}
+	}
+
+	// Act & Assert - The lenient decoder still ignores unknown fields
+	if _, err := policy.FromJSON(`{"id": "p", "unknown": 1, "created_at": "2023-05-01T10:00:00Z"}`); err != nil {
+		t.Errorf("FromJSON should remain lenient: %v", err)
+	}
+}
+
+func TestFromJSONListStrict(t *testing.T) {
+	// Act
+	_, err := policy.FromJSONListStrict(`{"policies": [{"id": "p", "statments": []}]}`)
+
+	// Assert
+	var strictErr *policy.StrictJSONError
+	if !errors.As(err, &strictErr) || strictErr.Path != "$.policies[0].statments" {
+		t.Errorf("Expected unknown field error inside the list, got %v", err)
+	}
+}
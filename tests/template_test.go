@@ -0,0 +1,267 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/template"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+const tenantTemplateJSON = `{
+	"id": "tenant-docs",
+	"version": "1",
+	"parameters": [
+		{"name": "tenant", "type": "string"},
+		{"name": "members", "type": "list", "default": ["alice"]},
+		{"name": "max_size", "type": "number", "default": 10}
+	],
+	"policy": {
+		"id": "${tenant}-docs",
+		"name": "Docs for ${tenant}",
+		"statements": [{
+			"id": "read",
+			"effect": "Allow",
+			"principals": ["${members}", "svc:ci"],
+			"actions": ["docs:read"],
+			"resources": ["tenants/${tenant}/docs/*"],
+			"conditions": [
+				{"operator": "NumericLessThanEquals", "key": "size", "value": "${max_size}"}
+			]
+		}]
+	}
+}`
+
+func tenantTemplate(t *testing.T) template.Template {
+	var tmpl template.Template
+	if err := json.Unmarshal([]byte(tenantTemplateJSON), &tmpl); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	return tmpl
+}
+
+func TestTemplateRender(t *testing.T) {
+	// Arrange
+	tmpl := tenantTemplate(t)
+	renderer := template.NewRenderer()
+
+	// Act
+	p, err := renderer.Render(tmpl, map[string]interface{}{"tenant": "acme", "members": []string{"alice", "bob"}})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if p.ID != "acme-docs" || p.Name != "Docs for acme" || p.Version != policy.PolicyVersion || p.CreatedAt.IsZero() {
+		t.Errorf("Unexpected policy fields: %+v", p)
+	}
+	statement := p.Statements[0]
+	if statement.Resources[0] != "tenants/acme/docs/*" {
+		t.Errorf("Expected substituted resource, got %v", statement.Resources)
+	}
+	if !reflect.DeepEqual(statement.Principals, []policy.Principal{"alice", "bob", "svc:ci"}) {
+		t.Errorf("Expected expanded principals, got %v", statement.Principals)
+	}
+	if statement.Conditions[0].Value != json.Number("10") {
+		t.Errorf("Expected numeric default, got %#v", statement.Conditions[0].Value)
+	}
+	if p.Template == nil || p.Template.ID != "tenant-docs" || p.Template.Version != "1" || len(p.Template.Parameters) != 2 {
+		t.Errorf("Expected template reference with the given values, got %+v", p.Template)
+	}
+	if errs := validator.NewDefaultValidator().Validate(p); validator.ValidationErrors(errs).HasErrors() {
+		t.Errorf("Expected rendered policy to be valid, got %v", errs)
+	}
+	engine := factory.NewEvaluatorFactory().CreatePolicyEvaluator(p)
+	req := evaluator.Request{Principal: "bob", Action: "docs:read", Resource: "tenants/acme/docs/a", Context: map[string]interface{}{"size": 4}}
+	if !engine.Evaluate(req).Allowed {
+		t.Errorf("Expected rendered policy to allow %+v", req)
+	}
+}
+
+func TestTemplateRenderParameterErrors(t *testing.T) {
+	// Arrange
+	tmpl := tenantTemplate(t)
+	tmpl.Policy.Name = "Docs for ${tenant} ${members} ${unknown}"
+
+	// Act
+	_, missingErr := template.NewRenderer().Render(tmpl, map[string]interface{}{"max_size": "big", "region": "eu"})
+	_, placeholderErr := template.NewRenderer().Render(tmpl, map[string]interface{}{"tenant": "acme"})
+
+	// Assert
+	var errs validator.ValidationErrors
+	if !errors.As(missingErr, &errs) {
+		t.Fatalf("Expected validation errors, got %v", missingErr)
+	}
+	codes := map[validator.ErrorCode]string{}
+	for _, err := range errs {
+		codes[err.Code] = err.Pointer
+	}
+	if codes[validator.CodeRequired] != "/values/tenant" || codes[validator.CodeInvalidParameter] != "/values/max_size" ||
+		codes[validator.CodeUnknownParameter] != "/values/region" {
+		t.Errorf("Unexpected parameter errors: %v", errs)
+	}
+	if !errors.As(placeholderErr, &errs) || len(errs) != 2 {
+		t.Fatalf("Expected list and unknown placeholder errors, got %v", placeholderErr)
+	}
+}
+
+func TestTemplateReapply(t *testing.T) {
+	// Arrange
+	tmpl := tenantTemplate(t)
+	renderer := template.NewRenderer()
+	rendered, err := renderer.Render(tmpl, map[string]interface{}{"tenant": "acme"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	data, _ := rendered.ToJSON()
+	stored, err := policy.FromJSON(data)
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	updated := tmpl
+	updated.Version = "2"
+	updated.Parameters = append([]template.Parameter{}, tmpl.Parameters...)
+	updated.Parameters[2].Default = 20
+
+	// Act
+	same, sameChanged, sameErr := renderer.Reapply(tmpl, stored)
+	next, changed, err := renderer.Reapply(updated, stored)
+	_, _, otherErr := renderer.Reapply(template.Template{ID: "other"}, stored)
+
+	// Assert
+	if sameErr != nil || sameChanged || !reflect.DeepEqual(same, stored) {
+		t.Errorf("Expected unchanged template to leave the policy as is, got %v %v", sameChanged, sameErr)
+	}
+	if err != nil || !changed {
+		t.Fatalf("Expected changed template to update the policy, got %v %v", changed, err)
+	}
+	if next.Statements[0].Conditions[0].Value != json.Number("20") || next.Template.Version != "2" ||
+		!next.CreatedAt.Equal(stored.CreatedAt) || next.UpdatedAt.IsZero() {
+		t.Errorf("Unexpected re-applied policy: %s", mustJSON(next))
+	}
+	if otherErr == nil {
+		t.Errorf("Expected a template ID mismatch to fail")
+	}
+}
+
+func TestTemplateRenderRejectsPatternValues(t *testing.T) {
+	// Arrange
+	tmpl := tenantTemplate(t)
+	renderer := template.NewRenderer()
+	patterns := tenantTemplate(t)
+	patterns.Parameters[0].AllowPatterns = true
+	patterns.Parameters[1].AllowPatterns = true
+
+	// Act
+	_, wildcardErr := renderer.Render(tmpl, map[string]interface{}{"tenant": "*", "members": []string{"alice", "team/*"}})
+	_, separatorErr := renderer.Render(tmpl, map[string]interface{}{"tenant": "acme/docs"})
+	_, emptyErr := renderer.Render(tmpl, map[string]interface{}{"tenant": "acme", "members": []string{}})
+	allowed, allowedErr := renderer.Render(patterns, map[string]interface{}{"tenant": "*", "members": []string{"team/*"}})
+
+	// Assert
+	var errs validator.ValidationErrors
+	if !errors.As(wildcardErr, &errs) || len(errs) != 2 {
+		t.Fatalf("Expected both wildcard values to be rejected, got %v", wildcardErr)
+	}
+	pointers := map[string]bool{}
+	for _, err := range errs {
+		if err.Code != validator.CodeInvalidParameter {
+			t.Errorf("Expected %s, got %s", validator.CodeInvalidParameter, err.Code)
+		}
+		pointers[err.Pointer] = true
+	}
+	if !pointers["/policy/statements/0/principals/0"] || !pointers["/policy/statements/0/resources/0"] {
+		t.Errorf("Unexpected pointers: %v", errs)
+	}
+	if !errors.As(separatorErr, &errs) || len(errs) != 1 {
+		t.Errorf("Expected the separator in a resource value to be rejected, got %v", separatorErr)
+	}
+	if allowedErr != nil || allowed.Statements[0].Resources[0] != "tenants/*/docs/*" {
+		t.Errorf("Expected allow_patterns to permit patterns, got %v %+v", allowedErr, allowed.Statements)
+	}
+
+	// Act - An empty list must not remove every action or resource
+	emptyActions := tenantTemplate(t)
+	emptyActions.Parameters = append(emptyActions.Parameters, template.Parameter{Name: "actions", Type: template.List})
+	emptyActions.Policy.Statements[0].Actions = []policy.Action{"${actions}"}
+	_, emptyActionsErr := renderer.Render(emptyActions, map[string]interface{}{"tenant": "acme", "actions": []string{}})
+
+	// Assert
+	if emptyErr != nil {
+		t.Errorf("Principals remaining after an empty expansion should be accepted, got %v", emptyErr)
+	}
+	if !errors.As(emptyActionsErr, &errs) || len(errs) != 1 || errs[0].Pointer != "/policy/statements/0/actions" {
+		t.Errorf("Expected an empty action list to be reported, got %v", emptyActionsErr)
+	}
+}
+
+func TestTemplateRendererOptions(t *testing.T) {
+	// Arrange
+	created := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	clock := condition.NewFixedClock(created)
+	renderer := template.NewRenderer(template.WithClock(clock), template.WithResourceSeparator(":"))
+	tmpl := tenantTemplate(t)
+	tmpl.Parameters = append(tmpl.Parameters, template.Parameter{Name: "team", Type: template.String, Default: "docs"})
+	tmpl.Policy.Statements[0].Conditions = append(tmpl.Policy.Statements[0].Conditions,
+		policy.Condition{Operator: policy.StringLike, Key: "user.team", Value: "${team}-*"},
+		policy.Condition{Operator: policy.StringMatchesRegex, Key: "user.group", Value: "^${tenant}$"},
+	)
+
+	// Act
+	rendered, err := renderer.Render(tmpl, map[string]interface{}{"tenant": "acme/eu"})
+	_, separatorErr := renderer.Render(tmpl, map[string]interface{}{"tenant": "acme:eu"})
+	_, likeErr := renderer.Render(tmpl, map[string]interface{}{"tenant": "acme", "team": "*"})
+	_, regexErr := renderer.Render(tmpl, map[string]interface{}{"tenant": "a.c"})
+
+	// Assert
+	if err != nil || !rendered.CreatedAt.Equal(created) {
+		t.Fatalf("Expected a policy created at the clock time, got %v %v", rendered.CreatedAt, err)
+	}
+	var errs validator.ValidationErrors
+	if !errors.As(separatorErr, &errs) || len(errs) != 1 {
+		t.Errorf("Expected the configured separator to be rejected, got %v", separatorErr)
+	}
+	if !errors.As(likeErr, &errs) || len(errs) != 1 || errs[0].Pointer != "/policy/statements/0/conditions/1/value" {
+		t.Errorf("Expected a wildcard in a StringLike value to be rejected, got %v", likeErr)
+	}
+	if !errors.As(regexErr, &errs) || len(errs) != 1 || errs[0].Pointer != "/policy/statements/0/conditions/2/value" {
+		t.Errorf("Expected a regex metacharacter to be rejected, got %v", regexErr)
+	}
+
+	// Act - Reapply stamps UpdatedAt from the clock
+	clock.Advance(time.Hour)
+	updated := tmpl
+	updated.Policy.Name = "Documents for ${tenant}"
+	next, changed, err := renderer.Reapply(updated, rendered)
+
+	// Assert
+	if err != nil || !changed || !next.UpdatedAt.Equal(created.Add(time.Hour)) {
+		t.Errorf("Expected UpdatedAt from the clock, got %v %v %v", next.UpdatedAt, changed, err)
+	}
+}
+
+func TestTemplateRenderValidatesPolicy(t *testing.T) {
+	// Arrange
+	tmpl := tenantTemplate(t)
+	tmpl.Policy.Statements[0].Effect = "${effect}"
+	tmpl.Parameters = append(tmpl.Parameters, template.Parameter{Name: "effect", Type: template.String})
+
+	// Act
+	_, err := template.NewRenderer().Render(tmpl, map[string]interface{}{"tenant": "acme", "effect": "Permit"})
+
+	// Assert
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) || !errs.HasErrors() {
+		t.Fatalf("Expected the rendered policy to fail validation, got %v", err)
+	}
+	if errs[0].Pointer != "/policy/statements/0/effect" {
+		t.Errorf("Expected a pointer into the template policy, got %q", errs[0].Pointer)
+	}
+}
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+func TestValidatorOptions(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	wide := policyFactory.CreatePolicy("policy-1", "Wide",
+		policyFactory.CreateStatement("s1", policy.Allow,
+			[]policy.Action{"read", "write", "delete"}, []policy.Resource{"doc:*"}),
+		policyFactory.CreateStatement("s2", policy.Allow,
+			[]policy.Action{"list"}, []policy.Resource{"doc:*"}),
+	)
+	noWildcardDelete := validator.RuleFunc(func(p policy.Policy) []validator.ValidationError {
+		var errs []validator.ValidationError
+		for i, statement := range p.Statements {
+			for _, action := range statement.Actions {
+				if action == "delete" {
+					errs = append(errs, validator.ValidationError{
+						Field:    "Statements",
+						Message:  "delete must be granted by a dedicated policy",
+						Code:     "dedicated_delete",
+						Severity: validator.SeverityWarning,
+						Pointer:  validator.JSONPointer("statements", i, "actions"),
+					})
+				}
+			}
+		}
+		return errs
+	})
+
+	// Act
+	errs := validator.NewValidator(
+		validator.WithMaxStatements(1),
+		validator.WithStatementLimits(2, 10, 10),
+		validator.WithRule(noWildcardDelete),
+	).Validate(wide)
+
+	// Assert
+	codes := make(map[validator.ErrorCode]int)
+	for _, err := range errs {
+		codes[err.Code]++
+	}
+	if codes[validator.CodeLimitExceeded] != 2 || codes["dedicated_delete"] != 1 {
+		t.Errorf("Expected statement and action limit errors plus the custom rule warning, got %v", errs)
+	}
+
+	// Act - Fail-fast stops at the first error
+	errs = validator.NewValidator(
+		validator.WithMaxStatements(1),
+		validator.WithStatementLimits(2, 10, 10),
+		validator.WithFailFast(),
+	).Validate(wide)
+
+	// Assert
+	if len(errs) != 1 || errs[0].Field != "Statements" {
+		t.Errorf("Fail-fast validation should return only the first error, got %v", errs)
+	}
+}
+
+func TestValidatorFactoryFromJSONConfig(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	unnamed := policyFactory.CreatePolicy("policy-1", "",
+		policyFactory.CreateStatement("s1", policy.Allow,
+			[]policy.Action{"read", "write"}, []policy.Resource{"doc:*"}),
+	)
+
+	// Act
+	validatorFactory, err := factory.NewValidatorFactoryFromConfig([]byte(`{"require_name": false, "max_actions_per_statement": 1}`))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to load validator config: %v", err)
+	}
+
+	errs := validatorFactory.CreatePolicyValidator().Validate(unnamed)
+	if len(errs) != 1 || errs[0].Field != "Statements[0].Actions" {
+		t.Errorf("Expected only the configured action limit error, got %v", errs)
+	}
+
+	// Act & Assert - Unspecified fields keep their defaults
+	config, _ := validator.LoadValidatorConfig([]byte(`{"fail_fast": true}`))
+	if !config.FailFast || config.MaxStatements != 100 || !config.RequireID {
+		t.Errorf("Unspecified config fields should keep defaults: %+v", config)
+	}
+
+	// Act & Assert - Invalid configs are rejected
+	if _, err := validator.LoadValidatorConfig([]byte(`{"max_statements": -1}`)); err == nil {
+		t.Errorf("Negative limits should be rejected")
+	}
+
+	if _, err := validator.LoadValidatorConfig([]byte(`{"max_statement": 5}`)); err == nil {
+		t.Errorf("Misspelled keys should be rejected")
+	}
+}
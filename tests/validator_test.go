@@ -1,9 +1,11 @@
 package tests
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
 	"github.com/CarlosHe/go-policy-management/pkg/policy/factory"
 	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
 )
@@ -206,3 +208,139 @@ func TestValidateCondition(t *testing.T) {
 		t.Errorf("Should include error for the Key field, but received: %v", errs)
 	}
 }
+
+func TestValidateConditionOperatorAndValue(t *testing.T) {
+	// Arrange
+	conditionValidator := validator.NewConditionValidator()
+	cases := []struct {
+		name      string
+		condition policy.Condition
+		field     string
+	}{
+		{"unknown operator", policy.Condition{Operator: "StringEqualz", Key: "user.role", Value: "admin"}, "Operator"},
+		{"string for numeric", policy.Condition{Operator: policy.NumericGreaterThan, Key: "user.age", Value: "eighteen"}, "Value"},
+		{"unparseable date", policy.Condition{Operator: policy.DateLessThan, Key: "request.time", Value: "yesterday"}, "Value"},
+		{"number for string", policy.Condition{Operator: policy.StringEquals, Key: "user.role", Value: 42}, "Value"},
+		{"string for bool", policy.Condition{Operator: policy.Bool, Key: "user.mfa", Value: "true"}, "Value"},
+		{"invalid cron", policy.Condition{Operator: policy.ScheduleCron, Value: "* * *"}, "Value"},
+	}
+
+	// Act & Assert
+	for _, c := range cases {
+		errs := conditionValidator.ValidateCondition(c.condition, 1, 2)
+		found := false
+		for _, err := range errs {
+			if err.Field == "Statements[1].Conditions[2]."+c.field {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: should include error for the %s field, but received: %v", c.name, c.field, errs)
+		}
+	}
+
+	// Act & Assert - Compatible values are accepted
+	valid := []policy.Condition{
+		{Operator: policy.NumericGreaterThan, Key: "user.age", Value: "18"},
+		{Operator: policy.NumericLessThan, Key: "order.amount", Value: 1000.5},
+		{Operator: policy.DateGreaterThan, Key: "token.issued_at", Value: "now-15m"},
+		{Operator: policy.DateLessThan, Key: "request.time", Value: "2023-05-01T10:00:00Z"},
+		{Operator: policy.StringEqualsIgnoreCase, Key: "user.role", Value: "Admin"},
+		{Operator: policy.Bool, Key: "user.mfa", Value: true},
+		{Operator: policy.ScheduleDayOfWeek, Value: []interface{}{"Mon", "Tue"}},
+	}
+	for _, cond := range valid {
+		if errs := conditionValidator.ValidateCondition(cond, 0, 0); len(errs) > 0 {
+			t.Errorf("Condition %v should be valid, got %v", cond, errs)
+		}
+	}
+}
+
+func TestValidateCustomOperator(t *testing.T) {
+	// Arrange
+	operators := condition.NewOperatorRegistry()
+	err := operators.Register(condition.CustomOperator{
+		Operator: "IpInRange",
+		Family:   policy.StringFamily,
+		Evaluate: func(contextValue, conditionValue interface{}) bool { return false },
+		ValidateValue: func(conditionValue interface{}) error {
+			if _, ok := conditionValue.(string); !ok {
+				return errors.New("CIDR string expected")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register custom operator: %v", err)
+	}
+	conditionValidator := validator.NewConditionValidatorWithOperators(operators)
+
+	// Act
+	validErrs := conditionValidator.ValidateCondition(policy.Condition{Operator: "IpInRange", Key: "source.ip", Value: "10.0.0.0/8"}, 0, 0)
+	invalidErrs := conditionValidator.ValidateCondition(policy.Condition{Operator: "IpInRange", Key: "source.ip", Value: 10}, 0, 0)
+
+	// Assert
+	if len(validErrs) > 0 {
+		t.Errorf("Registered custom operator should be accepted: %v", validErrs)
+	}
+
+	if len(invalidErrs) != 1 || invalidErrs[0].Field != "Statements[0].Conditions[0].Value" {
+		t.Errorf("Custom value validation should report a Value error, got %v", invalidErrs)
+	}
+
+	if operators.Register(condition.CustomOperator{Operator: policy.StringEquals, Evaluate: func(a, b interface{}) bool { return true }}) == nil {
+		t.Errorf("Built-in operators should not be redefinable")
+	}
+}
+
+func TestValidationErrorsAsGoErrors(t *testing.T) {
+	// Arrange
+	policyFactory := factory.NewPolicyFactory()
+	policyValidator := validator.NewDefaultValidator()
+	invalidPolicy := policyFactory.CreatePolicy(
+		"policy-1",
+		"Invalid Policy",
+		policyFactory.CreateStatement(
+			"statement-1",
+			policy.Allow,
+			[]policy.Action{"read"},
+			[]policy.Resource{"resource:*"},
+		),
+	)
+	invalidPolicy.Statements[0].Conditions = []policy.Condition{
+		{Operator: policy.StringEquals, Key: "user.role", Value: "admin"},
+		{Operator: "Bogus", Key: "user.role", Value: "admin"},
+	}
+
+	// Act
+	err := validator.ValidationErrors(policyValidator.Validate(invalidPolicy)).Err()
+
+	// Assert
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 1 {
+		t.Fatalf("Expected a single aggregated validation error, got %v", err)
+	}
+
+	got := validationErrs[0]
+	if got.Code != validator.CodeUnknownOperator || got.Severity != validator.SeverityError {
+		t.Errorf("Incorrect code or severity: %+v", got)
+	}
+
+	if got.Pointer != "/statements/0/conditions/1/operator" {
+		t.Errorf("Incorrect JSON pointer: %s", got.Pointer)
+	}
+
+	if got.Error() != `Statements[0].Conditions[1].Operator: Unknown condition operator "Bogus"` {
+		t.Errorf("Incorrect error message: %s", got.Error())
+	}
+
+	// Act & Assert - No errors yields a nil error
+	if err := validator.ValidationErrors(nil).Err(); err != nil {
+		t.Errorf("Empty error list should convert to nil, got %v", err)
+	}
+
+	// Act & Assert - Pointer tokens are escaped
+	if pointer := validator.JSONPointer("a/b", "c~d", 0); pointer != "/a~1b/c~0d/0" {
+		t.Errorf("Incorrect pointer escaping: %s", pointer)
+	}
+}
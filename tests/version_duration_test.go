@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/evaluator/condition"
+	"github.com/CarlosHe/go-policy-management/pkg/policy/validator"
+)
+
+func TestVersionPrecedence(t *testing.T) {
+	// Arrange - Ordered by SemVer 2.0 precedence
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.9.3",
+		"1.10.0",
+	}
+	versionEvaluator := condition.NewVersionEvaluator()
+
+	// Act & Assert
+	for i := 1; i < len(ordered); i++ {
+		if !versionEvaluator.LessThan(ordered[i-1], ordered[i]) {
+			t.Errorf("%s should be less than %s", ordered[i-1], ordered[i])
+		}
+		if !versionEvaluator.GreaterThan(ordered[i], ordered[i-1]) {
+			t.Errorf("%s should be greater than %s", ordered[i], ordered[i-1])
+		}
+	}
+
+	if !versionEvaluator.Equals("1.0.0+build.1", "v1.0.0+build.2") {
+		t.Errorf("Build metadata should not affect precedence")
+	}
+
+	if versionEvaluator.Equals("1.0", "1.0.0") {
+		t.Errorf("Incomplete versions should not match")
+	}
+}
+
+func TestDurationComparison(t *testing.T) {
+	// Arrange
+	durationEvaluator := condition.NewDurationEvaluator()
+
+	// Act & Assert
+	if !durationEvaluator.LessThan("90m", "2h") {
+		t.Errorf("90m should be less than 2h")
+	}
+
+	if !durationEvaluator.Equals("PT1H30M", "90m") {
+		t.Errorf("PT1H30M should equal 90m")
+	}
+
+	if !durationEvaluator.GreaterThan(36*time.Hour, "P1D") {
+		t.Errorf("36h should be greater than P1D")
+	}
+
+	if !durationEvaluator.Equals("P1W", "168h") {
+		t.Errorf("P1W should equal 168h")
+	}
+
+	if durationEvaluator.Equals("PT", "0s") || durationEvaluator.Equals("P", "0s") {
+		t.Errorf("Empty ISO 8601 durations should be rejected")
+	}
+}
+
+func TestValidateVersionAndDurationConditionValues(t *testing.T) {
+	// Arrange
+	conditionValidator := validator.NewConditionValidator()
+	cases := []struct {
+		condition policy.Condition
+		valid     bool
+	}{
+		{policy.Condition{Operator: policy.VersionGreaterThanEquals, Key: "client.version", Value: "1.10.0"}, true},
+		{policy.Condition{Operator: policy.VersionGreaterThanEquals, Key: "client.version", Value: "1.10"}, false},
+		{policy.Condition{Operator: policy.VersionLessThan, Key: "client.version", Value: 1.1}, false},
+		{policy.Condition{Operator: policy.DurationLessThan, Key: "session.age", Value: "PT2H"}, true},
+		{policy.Condition{Operator: policy.DurationLessThan, Key: "session.age", Value: "2 hours"}, false},
+	}
+
+	// Act & Assert
+	for _, c := range cases {
+		errs := conditionValidator.ValidateCondition(c.condition, 0, 0)
+		if c.valid && len(errs) > 0 {
+			t.Errorf("Condition %v should be valid, got %v", c.condition, errs)
+		}
+		if !c.valid {
+			found := false
+			for _, err := range errs {
+				if err.Field == "Statements[0].Conditions[0].Value" {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Condition %v should have a Value error, got %v", c.condition, errs)
+			}
+		}
+	}
+}
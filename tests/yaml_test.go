@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CarlosHe/go-policy-management/pkg/policy"
+)
+
+const yamlPolicy = `version: "2023-01-01"
+id: policy-1
+name: Order Approvals
+description: Approvals for small orders
+statements:
+  - id: statement-1
+    effect: Allow
+    actions: [approve, "read*"]
+    resources:
+      - order:*
+    conditions:
+      - operator: NumericLessThanEquals
+        key: order.amount
+        value: 1000.50
+      - operator: StringEquals
+        key: user.role
+        value: manager
+created_at: 2023-05-01T10:00:00Z
+updated_at: "2023-05-02T10:00:00Z"
+`
+
+func TestFromYAML(t *testing.T) {
+	// Act
+	result, err := policy.FromYAML(yamlPolicy)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to parse YAML policy: %v", err)
+	}
+
+	if result.ID != "policy-1" || result.Version != policy.PolicyVersion || len(result.Statements) != 1 {
+		t.Errorf("Incorrect policy fields: %+v", result)
+	}
+
+	if !result.CreatedAt.Equal(time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)) || !result.UpdatedAt.Equal(time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect timestamps: %v %v", result.CreatedAt, result.UpdatedAt)
+	}
+
+	statement := result.Statements[0]
+	if statement.Effect != policy.Allow || len(statement.Actions) != 2 || statement.Actions[1] != "read*" {
+		t.Errorf("Incorrect statement: %+v", statement)
+	}
+
+	if statement.Conditions[0].Value != json.Number("1000.50") {
+		t.Errorf("Numeric values should be decoded as json.Number without rounding, got %#v", statement.Conditions[0].Value)
+	}
+}
+
+func TestYAMLRoundTripMatchesJSON(t *testing.T) {
+	// Arrange
+	original, err := policy.FromYAML(yamlPolicy)
+	if err != nil {
+		t.Fatalf("Failed to parse YAML policy: %v", err)
+	}
+	originalJSON, _ := original.ToJSON()
+
+	// Act - JSON -> Policy -> YAML -> Policy -> JSON
+	fromJSON, err := policy.FromJSON(originalJSON)
+	if err != nil {
+		t.Fatalf("Failed to parse JSON policy: %v", err)
+	}
+	encoded, err := fromJSON.ToYAML()
+	if err != nil {
+		t.Fatalf("Failed to encode YAML: %v", err)
+	}
+	decoded, err := policy.FromYAML(encoded)
+	if err != nil {
+		t.Fatalf("Failed to decode encoded YAML: %v\n%s", err, encoded)
+	}
+	roundTripJSON, _ := decoded.ToJSON()
+
+	// Assert
+	if roundTripJSON != originalJSON {
+		t.Errorf("Round trip changed the policy:\n%s\n%s", originalJSON, roundTripJSON)
+	}
+
+	if !reflect.DeepEqual(decoded, fromJSON) {
+		t.Errorf("Decoded YAML policy differs from the JSON policy:\n%+v\n%+v", decoded, fromJSON)
+	}
+}
+
+func TestYAMLListAndArray(t *testing.T) {
+	// Arrange
+	first, _ := policy.FromYAML(yamlPolicy)
+	second := first
+	second.ID = "policy-2"
+
+	// Act
+	encoded, err := policy.ToYAMLList([]policy.Policy{first, second})
+	if err != nil {
+		t.Fatalf("Failed to encode policy list: %v", err)
+	}
+	list, err := policy.FromYAMLList(encoded)
+
+	// Assert
+	if err != nil || len(list) != 2 || list[1].ID != "policy-2" {
+		t.Errorf("Incorrect policy list: %v %+v", err, list)
+	}
+
+	array, err := policy.FromYAMLArray("- " + strings.ReplaceAll(strings.TrimSpace(yamlPolicy), "\n", "\n  "))
+	if err != nil || len(array) != 1 || array[0].ID != "policy-1" {
+		t.Errorf("Incorrect policy array: %v %+v", err, array)
+	}
+}
+
+func TestYAMLDecodeErrorsHavePositions(t *testing.T) {
+	cases := []struct {
+		name   string
+		yaml   string
+		line   int
+		column int
+	}{
+		{
+			name:   "invalid created_at",
+			yaml:   "id: p\ncreated_at: yesterday\n",
+			line:   2,
+			column: 13,
+		},
+		{
+			name:   "wrong type for actions",
+			yaml:   "id: p\ncreated_at: 2023-05-01T10:00:00Z\nstatements:\n  - effect: Allow\n    actions: {read: true}\n",
+			line:   5,
+			column: 14,
+		},
+		{
+			name:   "syntax error",
+			yaml:   "id: p\n  name: [\n",
+			line:   2,
+			column: 0,
+		},
+	}
+
+	for _, c := range cases {
+		// Act
+		_, err := policy.FromYAML(c.yaml)
+
+		// Assert
+		var yamlErr *policy.YAMLError
+		if !errors.As(err, &yamlErr) {
+			t.Errorf("%s: expected a YAMLError, got %v", c.name, err)
+			continue
+		}
+		if yamlErr.Line != c.line || yamlErr.Column != c.column {
+			t.Errorf("%s: expected position %d:%d, got %v", c.name, c.line, c.column, yamlErr)
+		}
+	}
+}